@@ -1,10 +1,11 @@
 package checkers
 
 import (
+	"bytes"
 	"errors"
-	"fmt"
 	"slices"
-	"strings"
+
+	"github.com/idelchi/wslint/internal/linter"
 )
 
 var (
@@ -15,16 +16,14 @@ var (
 )
 
 // Blanks is a checker that checks for trailing empty lines at the end of a sequence of lines.
-// It returns an error if there are no blank lines at the end of the file or if there are more than one.
-// It returns the formatted lines with the correct number of blank lines at the end of the file.
+// It reports an issue if there are no blank lines at the end of the file or if there are more than one,
+// along with the edits adding or removing blank lines.
 type Blanks struct{}
 
-// check checks for trailing empty lines at the end of a sequence of lines.
-// It returns the rows that are blank (at the end).
-func (b Blanks) check(lines []string) (rows []int) {
+// rows returns the rows that are blank (at the end).
+func (b Blanks) rows(lines [][]byte) (rows []int) {
 	for i := len(lines) - 1; i >= 0; i-- {
-		line := strings.TrimSpace(lines[i])
-		if line == "" {
+		if isBlank(lines[i]) {
 			// Blank line, record the row number.
 			rows = append(rows, i)
 		} else {
@@ -39,46 +38,100 @@ func (b Blanks) check(lines []string) (rows []int) {
 	return
 }
 
-// assert returns an error based on the number of blank lines at the end of the sequence of lines.
-func (b Blanks) assert(rows []int) []error {
+// assert returns the issues based on the number of blank lines at the end of the sequence of lines.
+// The number of lines and the length of the last one locate the missing blank line.
+func (b Blanks) assert(rows []int, count, last int) []linter.Issue {
 	switch blanks := len(rows); blanks {
 	// no blank lines at the end
 	case 0:
-		return []error{ErrTooFewBlanks}
+		line := max(count, 1)
+
+		return []linter.Issue{{
+			Line:      line,
+			Column:    last + 1,
+			EndColumn: last + 1,
+			Message:   ErrTooFewBlanks.Error(),
+			Fix:       "add a blank line",
+			Edits:     []linter.Edit{{Line: line, Column: last + 1, EndLine: line, EndColumn: last + 1, Text: "\n"}},
+		}}
 	// one blank line at the end
 	case 1:
 		return nil
 	// more than one blank line at the end
 	default:
-		// TODO(Idelchi): Would be clearer to the user if the row values are incremented by 1.
-		return []error{fmt.Errorf("%w: rows %v", ErrTooManyBlanks, rows)}
+		// The last row is the (empty) remainder after the final newline, report the blank lines before it.
+		issues := make([]linter.Issue, 0, blanks-1)
+
+		for _, row := range rows[:blanks-1] {
+			issues = append(issues, linter.Issue{
+				Line:      row + 1,
+				Column:    1,
+				EndColumn: 1,
+				Message:   ErrTooManyBlanks.Error(),
+				Fix:       "remove the blank line",
+				Edits:     []linter.Edit{{Line: row + 1, Column: 1, EndLine: row + 2, EndColumn: 1}},
+			})
+		}
+
+		return issues
 	}
 }
 
-// format returns the formatted lines with the correct number of blank lines at the end of the sequence of lines.
-func (b Blanks) format(lines []string, rows []int) []string {
-	switch blanks := len(rows); blanks {
-	// no blank lines at the end
-	case 0:
-		return append(lines, "")
-	// one blank line at the end
-	case 1:
-		return lines
-	// more than one blank line at the end
-	default:
-		return lines[:rows[1]]
+// Check checks the correctness of the sequence of lines in terms of blank lines at the end,
+// and returns the issues found.
+func (b Blanks) Check(lines [][]byte) []linter.Issue {
+	var last int
+	if len(lines) > 0 {
+		last = len(lines[len(lines)-1])
 	}
+
+	return b.assert(b.rows(lines), len(lines), last)
 }
 
-// Format checks the correctness of the sequence of lines in terms of blank lines at the end,
-// applies the formatting if needed and returns the formatted lines along with the errors.
-func (b Blanks) Format(lines []string) ([]string, []error) {
-	rows := b.check(lines)
-	errs := b.assert(rows)
+// Stream returns a stream enforcing exactly one blank line at the end.
+// Only the current run of blank lines is held back, until a non-blank line or the end is reached.
+func (b Blanks) Stream() linter.Stream {
+	return &blanksStream{}
+}
+
+// blanksStream keeps track of the current run of blank lines, until it is known whether they are at the end.
+type blanksStream struct {
+	row     int
+	last    int
+	pending int
+}
+
+// Next counts the blank lines in the current run.
+func (s *blanksStream) Next(line []byte) []linter.Issue {
+	if isBlank(line) {
+		s.pending++
+	} else {
+		s.pending = 0
+	}
+
+	s.row++
+	s.last = len(line)
 
-	if len(errs) == 0 {
-		return lines, errs
+	return nil
+}
+
+// Held returns the number of lines in the current run of blank lines, along with the line before it,
+// where a missing blank line is added.
+func (s *blanksStream) Held() int {
+	return s.pending + 1
+}
+
+// Close returns the issues found for the blank lines at the end.
+func (s *blanksStream) Close() []linter.Issue {
+	rows := make([]int, s.pending)
+	for i := range rows {
+		rows[i] = s.row - s.pending + i
 	}
 
-	return b.format(lines, rows), errs
+	return Blanks{}.assert(rows, s.row, s.last)
+}
+
+// isBlank returns true if the line contains only whitespace.
+func isBlank(line []byte) bool {
+	return len(bytes.TrimSpace(line)) == 0
 }
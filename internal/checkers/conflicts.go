@@ -0,0 +1,154 @@
+package checkers
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+// ErrConflict is returned when there are merge conflict markers.
+var ErrConflict = errors.New("merge conflict")
+
+// Conflicts keeps track of the markers left by unresolved merge conflicts.
+// A conflict starts with "<<<<<<<", optionally followed by the common ancestor after "|||||||" (diff3 style),
+// continues with "=======", and ends with ">>>>>>>", each at the start of a line.
+type Conflicts struct{}
+
+// marker returns the conflict marker starting the line (e.g. '<' for "<<<<<<< HEAD"), or 0 if there is none.
+// Markers are exactly seven characters long, and followed by a space or the end of the line,
+// with "=======" standing alone.
+func marker(line []byte) byte {
+	line = bytes.TrimSuffix(line, []byte("\r"))
+
+	const size = 7
+
+	if len(line) < size || !bytes.ContainsAny(line[:1], "<|=>") {
+		return 0
+	}
+
+	char := line[0]
+
+	if !bytes.Equal(line[:size], bytes.Repeat([]byte{char}, size)) {
+		return 0
+	}
+
+	switch rest := line[size:]; {
+	case len(rest) == 0:
+		return char
+	case char != '=' && rest[0] == ' ':
+		return char
+	default:
+		return 0
+	}
+}
+
+// Check checks the lines for merge conflict markers, and returns an issue for each line of a conflict.
+func (c Conflicts) Check(lines [][]byte) (issues []linter.Issue) {
+	stream := c.Stream()
+
+	for _, line := range lines {
+		issues = append(issues, stream.Next(line)...)
+	}
+
+	return append(issues, stream.Close()...)
+}
+
+// Stream returns a stream checking for merge conflict markers line by line.
+func (c Conflicts) Stream() linter.Stream {
+	return &conflictsStream{}
+}
+
+// conflictsStream checks for merge conflict markers line by line.
+type conflictsStream struct {
+	row int
+	// lengths holds the lengths of the lines of the open conflict, if any.
+	lengths []int
+	// start is the (0-based) row of the open conflict.
+	start int
+	// depth is the number of conflicts opened and not yet terminated, nested conflicts included.
+	depth int
+}
+
+// Next checks the line for merge conflict markers. The lines of a conflict are reported once it ends.
+func (s *conflictsStream) Next(line []byte) (issues []linter.Issue) {
+	defer func() { s.row++ }()
+
+	switch marker(line) {
+	case '<':
+		// Nested conflicts are part of the enclosing one, which ends with the marker at the same depth.
+		if s.depth == 0 {
+			s.start = s.row
+		}
+
+		s.depth++
+		s.lengths = append(s.lengths, len(line))
+	case '>':
+		if s.depth == 0 {
+			return []linter.Issue{conflict(s.row, len(line), "stray marker")}
+		}
+
+		s.depth--
+		s.lengths = append(s.lengths, len(line))
+
+		if s.depth == 0 {
+			issues = s.report()
+		}
+	case '|':
+		if s.depth == 0 {
+			return []linter.Issue{conflict(s.row, len(line), "stray marker")}
+		}
+
+		s.lengths = append(s.lengths, len(line))
+	default:
+		// "=======" outside of a conflict is e.g. the underline of a heading.
+		if s.depth > 0 {
+			s.lengths = append(s.lengths, len(line))
+		}
+	}
+
+	return issues
+}
+
+// report returns an issue for each line of the open conflict, and closes it.
+func (s *conflictsStream) report() []linter.Issue {
+	issues := make([]linter.Issue, 0, len(s.lengths))
+
+	for i, length := range s.lengths {
+		issues = append(issues, conflict(s.start+i, length, ""))
+	}
+
+	s.lengths = nil
+
+	return issues
+}
+
+// conflict returns the issue for a line of a conflict, at the given (0-based) row, spanning the whole line.
+func conflict(row, length int, detail string) linter.Issue {
+	message := ErrConflict.Error()
+	if detail != "" {
+		message += " (" + detail + ")"
+	}
+
+	return linter.Issue{
+		Line:      row + 1,
+		Column:    1,
+		EndColumn: length + 1,
+		Message:   message,
+	}
+}
+
+// Held returns 0, as the markers are not fixed.
+func (s *conflictsStream) Held() int {
+	return 0
+}
+
+// Close returns the issue for a conflict that is not terminated, reported on its opening marker only,
+// rather than on every remaining line of the file.
+func (s *conflictsStream) Close() []linter.Issue {
+	if s.depth == 0 {
+		return nil
+	}
+
+	return []linter.Issue{conflict(s.start, s.lengths[0], "unterminated")}
+}
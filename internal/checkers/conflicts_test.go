@@ -0,0 +1,94 @@
+package checkers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+)
+
+func TestConflicts(t *testing.T) {
+	t.Parallel()
+
+	// Test cases for the Conflicts struct.
+	tcs := []struct {
+		name    string         // Name of the test case (for logging)
+		lines   []string       // Lines to check
+		issues  map[int]string // Expected messages, by line number
+		comment string         // Comment in case of failure
+	}{
+		{
+			name:    "no conflict",
+			lines:   []string{"Title", "=======", "<<<<<<<<", ">>>>>>>x", "a <<<<<<< b"},
+			comment: "Headings, longer runs and markers within a line are not conflicts.",
+		},
+		{
+			name:  "conflict",
+			lines: []string{"a", "<<<<<<< HEAD", "ours", "=======", "theirs", ">>>>>>> feature", "b"},
+			issues: map[int]string{
+				2: "merge conflict", 3: "merge conflict", 4: "merge conflict", 5: "merge conflict", 6: "merge conflict",
+			},
+			comment: "All lines of the conflict are reported.",
+		},
+		{
+			name:  "diff3 conflict",
+			lines: []string{"<<<<<<< HEAD", "ours", "||||||| base", "base", "=======", "theirs", ">>>>>>> feature\r"},
+			issues: map[int]string{
+				1: "merge conflict", 2: "merge conflict", 3: "merge conflict", 4: "merge conflict",
+				5: "merge conflict", 6: "merge conflict", 7: "merge conflict",
+			},
+			comment: "The common ancestor is part of the conflict, and carriage returns are ignored.",
+		},
+		{
+			name: "nested conflict",
+			lines: []string{
+				"x", "<<<<<<< ours", "a", "<<<<<<< inner", "b", "=======", "c", ">>>>>>> inner", "=======", "d",
+				">>>>>>> other", "y",
+			},
+			issues: map[int]string{
+				2: "merge conflict", 3: "merge conflict", 4: "merge conflict", 5: "merge conflict",
+				6: "merge conflict", 7: "merge conflict", 8: "merge conflict", 9: "merge conflict",
+				10: "merge conflict", 11: "merge conflict",
+			},
+			comment: "A nested conflict is part of the enclosing one, which ends with its own marker.",
+		},
+		{
+			name:    "unterminated conflict",
+			lines:   []string{"a", "<<<<<<< HEAD", "ours", "=======", "theirs", ""},
+			issues:  map[int]string{2: "merge conflict (unterminated)"},
+			comment: "An unterminated conflict is reported on its opening marker only.",
+		},
+		{
+			name:    "unterminated nested conflict",
+			lines:   []string{"<<<<<<< ours", "a", "<<<<<<< inner", "b", ">>>>>>> inner", "c"},
+			issues:  map[int]string{1: "merge conflict (unterminated)"},
+			comment: "The opening marker of the enclosing conflict is reported.",
+		},
+		{
+			name:    "stray marker",
+			lines:   []string{"a", ">>>>>>> feature", "||||||| base"},
+			issues:  map[int]string{2: "merge conflict (stray marker)", 3: "merge conflict (stray marker)"},
+			comment: "Markers outside of a conflict are reported on their own.",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			messages := map[int]string{}
+
+			for _, issue := range (checkers.Conflicts{}).Check(toBytes(tc.lines)) {
+				require.Empty(t, issue.Edits, "edits: %s", tc.comment)
+
+				messages[issue.Line] = issue.Message
+			}
+
+			require.Equal(t, len(tc.issues), len(messages), "issues: %s", tc.comment)
+
+			for line, message := range tc.issues {
+				require.Equal(t, message, messages[line], "line %d: %s", line, tc.comment)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 // Package checkers contains string analysis tooling.
-// Each checker operates on a slice of strings.
+// Each checker operates on a slice of lines (byte slices), and can optionally process them one at a time as a stream.
 // Example checkers:
 // - Check for trailing whitespace
 // - Check for trailing empty line at the end of a sequence of lines
 // - Check for stuttering words
+// - Check for common misspellings
+// - Check for a header (e.g. a license notice) at the beginning
 package checkers
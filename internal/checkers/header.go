@@ -0,0 +1,259 @@
+package checkers
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+var (
+	// ErrNoHeader is returned when the header is missing.
+	ErrNoHeader = errors.New("missing header")
+	// ErrPlaceholder is returned when the template of a header contains an unknown placeholder.
+	ErrPlaceholder = errors.New("unknown placeholder")
+)
+
+// DefaultHeaderWithin is the number of lines the header must start within, if not set.
+const DefaultHeaderWithin = 10
+
+// bom is the UTF-8 byte order mark, preceding the header if present.
+const bom = "\xef\xbb\xbf"
+
+// placeholder matches the placeholders of a header template, e.g. "{{year}}".
+//
+//nolint:gochecknoglobals // The pattern is compiled once.
+var placeholder = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// yearPattern is the pattern matching the "{{year}}" placeholder in an existing header, e.g. "2024" or "2019-2024".
+const yearPattern = `\d{4}(?:\s*-\s*\d{4})?`
+
+// Header keeps track of the header (e.g. a license notice) that must be at the beginning of the lines.
+// The header may follow a shebang ("#!") or an XML declaration ("<?xml"), which are kept in place when the
+// header is inserted, as is a leading byte order mark.
+type Header struct {
+	// Template is the text of the header, without the comment syntax.
+	// The placeholder "{{year}}" stands for any year (or range of years), and the current one when inserted.
+	Template string
+	// Comment prefixes each line of the template, e.g. "// " or "# ".
+	Comment string
+	// Start and End are the lines enclosing the header, if not empty, e.g. "<!--" and "-->".
+	Start, End string
+	// Within is the number of lines (following a shebang or XML declaration) the header must start within.
+	// If 0, DefaultHeaderWithin applies.
+	Within int
+	// Year replaces "{{year}}" when inserting the header. If 0, the current year applies.
+	Year int
+}
+
+// Validate returns ErrPlaceholder if the template contains a placeholder other than "{{year}}".
+func (h Header) Validate() error {
+	for _, match := range placeholder.FindAllStringSubmatch(h.Template, -1) {
+		if match[1] != "year" {
+			return fmt.Errorf("%w: %q", ErrPlaceholder, match[0])
+		}
+	}
+
+	return nil
+}
+
+// lines returns the lines of the header, with the comment syntax and without trailing whitespace.
+func (h Header) lines() (lines []string) {
+	if h.Start != "" {
+		lines = append(lines, h.Start)
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(h.Template, "\n"), "\n") {
+		lines = append(lines, strings.TrimRightFunc(h.Comment+line, unicode.IsSpace))
+	}
+
+	if h.End != "" {
+		lines = append(lines, h.End)
+	}
+
+	return lines
+}
+
+// patterns returns the regular expressions matching the lines of the header, with any year for "{{year}}".
+func (h Header) patterns() []*regexp.Regexp {
+	lines := h.lines()
+	patterns := make([]*regexp.Regexp, 0, len(lines))
+
+	for _, line := range lines {
+		parts := placeholder.Split(line, -1)
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+
+		patterns = append(patterns, regexp.MustCompile("^"+strings.Join(parts, yearPattern)+"$"))
+	}
+
+	return patterns
+}
+
+// text returns the header to insert, with "{{year}}" replaced by the year.
+func (h Header) text() string {
+	year := h.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	return placeholder.ReplaceAllString(strings.Join(h.lines(), "\n"), strconv.Itoa(year))
+}
+
+// Check checks that the lines start with the header.
+func (h Header) Check(lines [][]byte) (issues []linter.Issue) {
+	stream := h.Stream()
+
+	for _, line := range lines {
+		issues = append(issues, stream.Next(line)...)
+	}
+
+	return append(issues, stream.Close()...)
+}
+
+// Stream returns a stream checking that the lines start with the header.
+// The first lines are held back until the header is found or known to be missing.
+func (h Header) Stream() linter.Stream {
+	return &headerStream{header: h, patterns: h.patterns(), within: cmp.Or(h.Within, DefaultHeaderWithin)}
+}
+
+// headerStream checks that the lines start with a header, line by line.
+type headerStream struct {
+	header   Header
+	patterns []*regexp.Regexp
+	within   int
+	// lines holds the lines seen until the header is found or known to be missing.
+	lines [][]byte
+	// prolog is the number of lines preceding the header, i.e. a shebang or an XML declaration.
+	prolog int
+	done   bool
+}
+
+// Next checks whether the lines seen so far start with the header.
+func (s *headerStream) Next(line []byte) []linter.Issue {
+	if s.done {
+		return nil
+	}
+
+	if len(s.lines) == 0 {
+		if first := bytes.TrimPrefix(line, []byte(bom)); bytes.HasPrefix(first, []byte("#!")) ||
+			bytes.HasPrefix(first, []byte("<?xml")) {
+			s.prolog = 1
+		}
+	}
+
+	s.lines = append(s.lines, bytes.Clone(line))
+
+	switch {
+	case s.found():
+		s.done = true
+		s.lines = nil
+
+		return nil
+	case len(s.lines) >= s.prolog+s.within+len(s.patterns)-1:
+		// The header cannot start on a later line.
+		return s.report(false)
+	default:
+		return nil
+	}
+}
+
+// found returns true if the header starts on one of the lines allowed.
+func (s *headerStream) found() bool {
+	for start := s.prolog; start < s.prolog+s.within && start+len(s.patterns) <= len(s.lines); start++ {
+		matches := true
+
+		for i, pattern := range s.patterns {
+			line := s.lines[start+i]
+			if start+i == 0 {
+				line = bytes.TrimPrefix(line, []byte(bom))
+			}
+
+			if !pattern.Match(bytes.TrimRightFunc(line, unicode.IsSpace)) {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// report returns the issue for the missing header, with the edit inserting it after the prolog (or byte order
+// mark), followed by a blank line if the next line is not blank. Once all lines are seen (final), lines that
+// are all blank need no header.
+func (s *headerStream) report(final bool) []linter.Issue {
+	s.done = true
+
+	lines := s.lines
+	s.lines = nil
+
+	if final && !slices.ContainsFunc(lines, func(line []byte) bool { return !isBlank(line) }) {
+		return nil
+	}
+
+	text := s.header.text()
+
+	var edit linter.Edit
+
+	switch {
+	case s.prolog > 0 && len(lines) == 1:
+		// Only the prolog, the header ends the lines.
+		edit = linter.Edit{Line: 1, Column: len(lines[0]) + 1, Text: "\n" + text}
+	case s.prolog > 0:
+		edit = linter.Edit{Line: 2, Column: 1, Text: text + "\n"}
+		if !isBlank(lines[1]) {
+			edit.Text += "\n"
+		}
+	default:
+		column := 1
+		if bytes.HasPrefix(lines[0], []byte(bom)) {
+			column += len(bom)
+		}
+
+		edit = linter.Edit{Line: 1, Column: column, Text: text + "\n"}
+		if !isBlank(lines[0][column-1:]) {
+			edit.Text += "\n"
+		}
+	}
+
+	edit.EndLine, edit.EndColumn = edit.Line, edit.Column
+
+	return []linter.Issue{{
+		Line:      edit.Line,
+		Column:    edit.Column,
+		EndColumn: edit.Column,
+		Message:   ErrNoHeader.Error(),
+		Fix:       "insert the header",
+		Edits:     []linter.Edit{edit},
+	}}
+}
+
+// Held returns the number of lines seen until the header is found or known to be missing, as the header is
+// inserted before them.
+func (s *headerStream) Held() int {
+	return len(s.lines)
+}
+
+// Close returns the issue for a missing header, if not reported yet.
+func (s *headerStream) Close() []linter.Issue {
+	if s.done {
+		return nil
+	}
+
+	return s.report(true)
+}
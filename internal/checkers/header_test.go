@@ -0,0 +1,136 @@
+package checkers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+func TestHeader(t *testing.T) {
+	t.Parallel()
+
+	header := checkers.Header{
+		Template: "SPDX-License-Identifier: MIT\nCopyright {{year}} Acme\n",
+		Comment:  "# ",
+		Year:     2026,
+	}
+
+	insert := func(line, column int, text string) []linter.Issue {
+		return []linter.Issue{{
+			Line: line, Column: column, EndColumn: column, Message: checkers.ErrNoHeader.Error(), Fix: "insert the header",
+			Edits: []linter.Edit{{Line: line, Column: column, EndLine: line, EndColumn: column, Text: text}},
+		}}
+	}
+
+	// Test cases for the Header struct.
+	tcs := []struct {
+		name    string          // Name of the test case (for logging)
+		header  checkers.Header // Header to check
+		lines   []string        // Lines to check
+		issues  []linter.Issue  // Issues expected
+		fixed   []string        // Lines after the fixes are applied
+		comment string          // Comment in case of failure
+	}{
+		{
+			name:    "present",
+			header:  header,
+			lines:   []string{"# SPDX-License-Identifier: MIT", "# Copyright 2019 - 2024 Acme", "", "code"},
+			fixed:   []string{"# SPDX-License-Identifier: MIT", "# Copyright 2019 - 2024 Acme", "", "code"},
+			comment: "Any year or range of years matches the placeholder.",
+		},
+		{
+			name:    "present after shebang and blank line",
+			header:  header,
+			lines:   []string{"#!/bin/sh", "", "# SPDX-License-Identifier: MIT", "# Copyright 2024 Acme\r", ""},
+			fixed:   []string{"#!/bin/sh", "", "# SPDX-License-Identifier: MIT", "# Copyright 2024 Acme\r", ""},
+			comment: "The header may follow a shebang, and trailing whitespace is ignored.",
+		},
+		{
+			name:   "missing",
+			header: header,
+			lines:  []string{"code", ""},
+			issues: insert(1, 1, "# SPDX-License-Identifier: MIT\n# Copyright 2026 Acme\n\n"),
+			fixed:  []string{"# SPDX-License-Identifier: MIT", "# Copyright 2026 Acme", "", "code", ""},
+		},
+		{
+			name:    "too late",
+			header:  checkers.Header{Template: "SPDX-License-Identifier: MIT", Comment: "// ", Within: 2},
+			lines:   []string{"", "", "// SPDX-License-Identifier: MIT"},
+			issues:  insert(1, 1, "// SPDX-License-Identifier: MIT\n"),
+			fixed:   []string{"// SPDX-License-Identifier: MIT", "", "", "// SPDX-License-Identifier: MIT"},
+			comment: "The header must start within the given number of lines.",
+		},
+		{
+			name:    "missing after shebang",
+			header:  header,
+			lines:   []string{"#!/usr/bin/env python3", "print()", ""},
+			issues:  insert(2, 1, "# SPDX-License-Identifier: MIT\n# Copyright 2026 Acme\n\n"),
+			fixed:   []string{"#!/usr/bin/env python3", "# SPDX-License-Identifier: MIT", "# Copyright 2026 Acme", "", "print()", ""},
+			comment: "The header is inserted after the shebang.",
+		},
+		{
+			name:    "missing after shebang only",
+			header:  header,
+			lines:   []string{"#!/bin/sh"},
+			issues:  insert(1, 10, "\n# SPDX-License-Identifier: MIT\n# Copyright 2026 Acme"),
+			fixed:   []string{"#!/bin/sh", "# SPDX-License-Identifier: MIT", "# Copyright 2026 Acme"},
+			comment: "The header is appended to a lone shebang.",
+		},
+		{
+			name:    "missing after byte order mark and XML declaration",
+			header:  checkers.Header{Template: "SPDX-License-Identifier: MIT", Start: "<!--", End: "-->"},
+			lines:   []string{"\xef\xbb\xbf<?xml version=\"1.0\"?>", "<root/>"},
+			issues:  insert(2, 1, "<!--\nSPDX-License-Identifier: MIT\n-->\n\n"),
+			fixed:   []string{"\xef\xbb\xbf<?xml version=\"1.0\"?>", "<!--", "SPDX-License-Identifier: MIT", "-->", "", "<root/>"},
+			comment: "The header is inserted after the XML declaration.",
+		},
+		{
+			name:    "missing after byte order mark",
+			header:  header,
+			lines:   []string{"\xef\xbb\xbfcode"},
+			issues:  insert(1, 4, "# SPDX-License-Identifier: MIT\n# Copyright 2026 Acme\n\n"),
+			fixed:   []string{"\xef\xbb\xbf# SPDX-License-Identifier: MIT", "# Copyright 2026 Acme", "", "code"},
+			comment: "The byte order mark stays at the beginning.",
+		},
+		{
+			name:    "present after byte order mark",
+			header:  checkers.Header{Template: "SPDX-License-Identifier: MIT", Comment: "// "},
+			lines:   []string{"\xef\xbb\xbf// SPDX-License-Identifier: MIT"},
+			fixed:   []string{"\xef\xbb\xbf// SPDX-License-Identifier: MIT"},
+			comment: "The byte order mark is ignored.",
+		},
+		{
+			name:    "blank",
+			header:  header,
+			lines:   []string{"", " ", ""},
+			fixed:   []string{"", " ", ""},
+			comment: "Blank lines need no header.",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lines := toBytes(tc.lines)
+
+			issues := tc.header.Check(lines)
+			require.Equal(t, tc.issues, issues, tc.comment)
+
+			fixed, conflicts := linter.Apply(lines, linter.Edits(issues))
+			require.Empty(t, conflicts)
+			require.Equal(t, toBytes(tc.fixed), fixed, tc.comment)
+
+			require.Empty(t, tc.header.Check(fixed), "fixed: %s", tc.comment)
+		})
+	}
+}
+
+func TestHeader_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, checkers.Header{Template: "Copyright {{ year }} Acme"}.Validate())
+	require.ErrorIs(t, checkers.Header{Template: "Copyright {{year}} {{owner}}"}.Validate(), checkers.ErrPlaceholder)
+}
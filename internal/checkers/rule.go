@@ -0,0 +1,102 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+// ErrForbidden is returned when the pattern of a rule without a message matches.
+var ErrForbidden = errors.New("forbidden")
+
+// Rule keeps track of the matches of a user-defined pattern that must not appear (e.g. leftover debug statements).
+type Rule struct {
+	// Pattern is the regular expression that must not match, matched line by line.
+	Pattern *regexp.Regexp
+	// Message describes the matches, or is empty to describe them as forbidden.
+	Message string
+	// Replacement is the template the matches are replaced with when fixing, with $1 or ${name} expanded to the
+	// submatches as in regexp.Regexp.Expand. If nil, the matches are not fixed.
+	Replacement *string
+	// Severity is the severity of the matches.
+	Severity linter.Severity
+}
+
+// find returns an issue for each match of the pattern in the line at the given (0-based) row.
+func (r Rule) find(row int, line []byte) (issues []linter.Issue) {
+	message := r.Message
+	if message == "" {
+		message = ErrForbidden.Error()
+	}
+
+	for _, match := range r.Pattern.FindAllSubmatchIndex(line, -1) {
+		// Empty matches are not reported.
+		if match[0] == match[1] {
+			continue
+		}
+
+		issue := linter.Issue{
+			Line:      row + 1,
+			Column:    match[0] + 1,
+			EndColumn: match[1] + 1,
+			Message:   fmt.Sprintf("%s (%s)", message, line[match[0]:match[1]]),
+			Severity:  r.Severity,
+		}
+
+		if r.Replacement != nil {
+			text := string(r.Pattern.Expand(nil, []byte(*r.Replacement), line, match))
+
+			issue.Fix = fmt.Sprintf("replace with %q", text)
+			issue.Edits = []linter.Edit{{
+				Line:      row + 1,
+				Column:    match[0] + 1,
+				EndLine:   row + 1,
+				EndColumn: match[1] + 1,
+				Text:      text,
+			}}
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
+// Check checks the lines for matches of the pattern.
+func (r Rule) Check(lines [][]byte) (issues []linter.Issue) {
+	for row, line := range lines {
+		issues = append(issues, r.find(row, line)...)
+	}
+
+	return issues
+}
+
+// Stream returns a stream checking for matches of the pattern line by line.
+func (r Rule) Stream() linter.Stream {
+	return &ruleStream{rule: r}
+}
+
+// ruleStream checks for matches of a pattern line by line.
+type ruleStream struct {
+	rule Rule
+	row  int
+}
+
+// Next checks the line for matches of the pattern.
+func (s *ruleStream) Next(line []byte) []linter.Issue {
+	defer func() { s.row++ }()
+
+	return s.rule.find(s.row, line)
+}
+
+// Held returns 0, as the matches are fixed within the line.
+func (s *ruleStream) Held() int {
+	return 0
+}
+
+// Close returns no issues, as all issues are found line by line.
+func (s *ruleStream) Close() []linter.Issue {
+	return nil
+}
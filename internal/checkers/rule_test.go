@@ -0,0 +1,99 @@
+package checkers_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+func TestRule(t *testing.T) {
+	t.Parallel()
+
+	replacement := func(s string) *string { return &s }
+
+	// Test cases for the Rule struct.
+	tcs := []struct {
+		name    string         // Name of the test case (for logging)
+		rule    checkers.Rule  // Rule to check, with the pattern set from the pattern field
+		pattern string         // Pattern of the rule
+		lines   []string       // Lines to check
+		issues  []linter.Issue // Issues expected
+		fixed   []string       // Lines after the fixes are applied
+	}{
+		{
+			name:    "no match",
+			pattern: `console\.log`,
+			lines:   []string{"console.info(1)"},
+			fixed:   []string{"console.info(1)"},
+		},
+		{
+			name:    "matches",
+			pattern: `console\.log`,
+			lines:   []string{"", "console.log(1); console.log(2)"},
+			issues: []linter.Issue{
+				{Line: 2, Column: 1, EndColumn: 12, Message: "forbidden (console.log)"},
+				{Line: 2, Column: 17, EndColumn: 28, Message: "forbidden (console.log)"},
+			},
+			fixed: []string{"", "console.log(1); console.log(2)"},
+		},
+		{
+			name:    "empty matches",
+			pattern: `x*`,
+			lines:   []string{"ab"},
+			fixed:   []string{"ab"},
+		},
+		{
+			name:    "message and severity",
+			rule:    checkers.Rule{Message: "debug output", Severity: linter.Warning},
+			pattern: `FIXME-NOW`,
+			lines:   []string{"// FIXME-NOW"},
+			issues: []linter.Issue{
+				{Line: 1, Column: 4, EndColumn: 13, Message: "debug output (FIXME-NOW)", Severity: linter.Warning},
+			},
+			fixed: []string{"// FIXME-NOW"},
+		},
+		{
+			name:    "replacement",
+			rule:    checkers.Rule{Replacement: replacement("${2}.$1")},
+			pattern: `(\w+)@(\w+)`,
+			lines:   []string{"user@host"},
+			issues: []linter.Issue{
+				{
+					Line: 1, Column: 1, EndColumn: 10, Message: "forbidden (user@host)", Fix: `replace with "host.user"`,
+					Edits: []linter.Edit{{Line: 1, Column: 1, EndLine: 1, EndColumn: 10, Text: "host.user"}},
+				},
+			},
+			fixed: []string{"host.user"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := tc.rule
+			checker.Pattern = regexp.MustCompile(tc.pattern)
+			lines := toBytes(tc.lines)
+
+			issues := checker.Check(lines)
+			require.Equal(t, tc.issues, issues)
+
+			stream := checker.Stream()
+
+			var streamed []linter.Issue
+
+			for _, line := range lines {
+				streamed = append(streamed, stream.Next(line)...)
+			}
+
+			require.Equal(t, tc.issues, append(streamed, stream.Close()...))
+
+			fixed, conflicts := linter.Apply(lines, linter.Edits(issues))
+			require.Empty(t, conflicts)
+			require.Equal(t, toBytes(tc.fixed), fixed)
+		})
+	}
+}
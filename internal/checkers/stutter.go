@@ -1,68 +1,214 @@
 package checkers
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
-	"slices"
 
+	"github.com/idelchi/wslint/internal/linter"
+	"github.com/idelchi/wslint/pkg/prose"
 	"github.com/idelchi/wslint/pkg/stuttering"
 )
 
 // ErrStutter is returned when there is stuttering.
 var ErrStutter = errors.New("stutters")
 
-// Stutter keeps track of stuttering words.
+// Stutter keeps track of stuttering words and repeated phrases.
 type Stutter struct {
-	Exceptions []string
+	// Exceptions lists the repeated words and phrases to ignore, regardless of their scope.
+	Exceptions stuttering.Exceptions
+	// Length is the maximum number of words of a repeated phrase, or 0 for stuttering.DefaultLength.
+	Length int
+	// Syntax restricts the checks to the prose of the lines (e.g. the comments and strings of source code).
+	// If empty, the whole lines are checked.
+	Syntax prose.Syntax
 }
 
-func (s Stutter) check(lines []string) (rows []int, stutters map[int][]string) {
-	stutters = make(map[int][]string)
-
-	for row, line := range lines {
-		if stuttering.Has(line) {
-			words := stuttering.Find(line)
+// find returns the issues for the stutters in the text, starting at the given (0-based) offset of the line
+// at the given (0-based) row, that are not exceptions.
+// Each issue carries the edit deleting the first occurrence of the stutter along with the separator following it,
+// leaving the rest of the line untouched.
+func (s Stutter) find(row, offset int, text []byte) (issues []linter.Issue) {
+	length := s.Length
+	if length == 0 {
+		length = stuttering.DefaultLength
+	}
 
-			for _, word := range words {
-				if !slices.Contains(s.Exceptions, word) {
-					stutters[row] = words
+	for _, repetition := range stuttering.FindRepetitions(string(text), length) {
+		if s.ignored(repetition) {
+			continue
+		}
 
-					rows = append(rows, row)
-				}
-			}
+		fix := "remove the repeated word"
+		if repetition.Length > 1 {
+			fix = "remove the repeated phrase"
 		}
+
+		issues = append(issues, linter.Issue{
+			Line:      row + 1,
+			Column:    offset + repetition.Index[0] + 1,
+			EndColumn: offset + repetition.Index[1] + 1,
+			Message:   fmt.Sprintf("%v %s", ErrStutter, repetition.Stutter),
+			Fix:       fix,
+			Edits: []linter.Edit{{
+				Line:      row + 1,
+				Column:    offset + repetition.Removal[0] + 1,
+				EndLine:   row + 1,
+				EndColumn: offset + repetition.Removal[1] + 1,
+			}},
+		})
 	}
 
 	return
 }
 
-func (s Stutter) assert(rows []int, stutters map[int][]string) (errors []error) {
-	if len(rows) > 0 {
-		for _, row := range rows {
-			// TODO(Idelchi): Would be clearer to the user if the row values are incremented by 1.
-			errors = append(errors, fmt.Errorf("%w: on line %d: words %v", ErrStutter, row, stutters[row]))
-		}
+// across returns the issue for a stutter formed by the last word of the previous text and the first word of
+// the text, unless it is an exception. Each text is given along with the (0-based) row and offset it starts at.
+// The issue is reported on the second word, and carries the edit deleting the first one, so that no text is reflowed.
+func (s Stutter) across(previous, current segment) []linter.Issue {
+	repetition, ok := stuttering.FindAcross(string(previous.text), string(current.text))
+	if !ok || s.ignored(repetition) {
+		return nil
 	}
 
-	return
+	edit := linter.Edit{
+		Line:      previous.row + 1,
+		Column:    previous.offset + repetition.Removal[0] + 1,
+		EndLine:   previous.row + 1,
+		EndColumn: previous.offset + repetition.Removal[1] + 1,
+	}
+
+	// A line holding nothing but the word is removed along with its newline, instead of being left blank.
+	if repetition.Sole && previous.starts {
+		edit = linter.Edit{Line: previous.row + 1, Column: 1, EndLine: previous.row + 2, EndColumn: 1}
+	}
+
+	return []linter.Issue{{
+		Line:      current.row + 1,
+		Column:    current.offset + repetition.Index[0] + 1,
+		EndColumn: current.offset + repetition.Index[1] + 1,
+		Message:   fmt.Sprintf("%v %s", ErrStutter, repetition.Stutter),
+		Fix:       "remove the repeated word",
+		Edits:     []linter.Edit{edit},
+	}}
 }
 
-func (s Stutter) format(lines []string, rows []int) []string {
-	for _, i := range rows {
-		lines[i] = stuttering.Trim(lines[i])
+// ignored returns true if the repetition is an exception.
+func (s Stutter) ignored(repetition stuttering.Repetition) bool {
+	return s.Exceptions.Match(repetition.Phrase)
+}
+
+// Check checks the lines for stuttering words, within a line and across line boundaries.
+func (s Stutter) Check(lines [][]byte) (issues []linter.Issue) {
+	stream := s.Stream()
+
+	for _, line := range lines {
+		issues = append(issues, stream.Next(line)...)
 	}
 
-	return lines
+	return append(issues, stream.Close()...)
 }
 
-// Format formats the lines.
-func (s Stutter) Format(lines []string) ([]string, []error) {
-	rows, stutters := s.check(lines)
-	errs := s.assert(rows, stutters)
+// Stream returns a stream checking for stuttering words line by line.
+func (s Stutter) Stream() linter.Stream {
+	return &stutterStream{
+		stutter:    s,
+		lexer:      s.Syntax.Lexer(),
+		delimiters: s.Syntax.Delimiters(),
+		markup:     s.Syntax.Markup(),
+	}
+}
+
+// segment is a piece of prose, at a (0-based) row and offset.
+type segment struct {
+	text        []byte
+	row, offset int
+	// starts is set if only whitespace or comment delimiters precede the prose in its line.
+	starts bool
+}
+
+// startsLine returns true if only whitespace or the given comment delimiters (e.g. "/*" for "//" and "/*")
+// precede the offset in the line.
+func startsLine(line []byte, offset int, delimiters string) bool {
+	return len(bytes.Trim(line[:offset], " \t"+delimiters)) == 0
+}
 
-	if len(errs) == 0 {
-		return lines, errs
+// stutterStream checks for stuttering words line by line.
+type stutterStream struct {
+	stutter Stutter
+	lexer   prose.Lexer
+	// delimiters are the comment delimiters that may precede prose continuing the previous line.
+	delimiters string
+	// markup is set if blank lines end the prose (as they separate blocks).
+	markup bool
+	row    int
+	// previous is the prose at the end of the last non-blank line, or nil if there is none
+	// (e.g. the line ended with code).
+	previous *segment
+}
+
+// Next checks the prose of the line for stuttering words, and its first word against the last word
+// of the prose ending the previous non-blank line, if the prose starts the line and both are in the same block.
+func (s *stutterStream) Next(line []byte) (issues []linter.Issue) {
+	defer func() { s.row++ }()
+
+	var (
+		last  *segment
+		block bool
+	)
+
+	for _, span := range s.lexer.Next(line) {
+		current := &segment{text: line[span[0]:span[1]], row: s.row, offset: span[0]}
+		if len(bytes.TrimSpace(current.text)) == 0 {
+			continue
+		}
+
+		if last == nil {
+			block = prose.Block(bytes.TrimLeft(current.text, " \t"+s.delimiters))
+		}
+
+		// Only prose starting its line continues the previous one, not prose following code, inline code
+		// or the delimiter of another kind of prose (e.g. a string after a comment), nor a heading or list item.
+		if last == nil && s.previous != nil && !block && startsLine(line, current.offset, s.delimiters) {
+			issues = append(issues, s.stutter.across(*s.previous, *current)...)
+		}
+
+		issues = append(issues, s.stutter.find(s.row, current.offset, current.text)...)
+		last = current
+	}
+
+	switch {
+	// A heading or list item is not continued by the next line, so that it is never edited by a stutter across
+	// lines.
+	case block:
+		s.previous = nil
+	// The prose ends the line, and may continue on the next non-blank line.
+	case last != nil && len(bytes.TrimSpace(line[last.offset+len(last.text):])) == 0:
+		last.text = bytes.Clone(last.text)
+		last.starts = startsLine(line, last.offset, s.delimiters)
+		s.previous = last
+	// Code interrupts the prose.
+	case len(bytes.TrimSpace(line)) > 0:
+		s.previous = nil
+	// A blank line ends the paragraph in markup.
+	case s.markup:
+		s.previous = nil
 	}
 
-	return s.format(lines, rows), errs
+	return issues
+}
+
+// Held returns the number of lines since the last non-blank line (included), if it ends with prose,
+// as its last word is removed if it stutters with the first word of the next non-blank line.
+func (s *stutterStream) Held() int {
+	if s.previous == nil {
+		return 0
+	}
+
+	return s.row - s.previous.row
+}
+
+// Close returns no issues, as all issues are found line by line.
+func (s *stutterStream) Close() []linter.Issue {
+	return nil
 }
@@ -0,0 +1,205 @@
+package checkers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/linter"
+	"github.com/idelchi/wslint/pkg/prose"
+	"github.com/idelchi/wslint/pkg/stuttering"
+)
+
+func TestStutter_Fix(t *testing.T) {
+	t.Parallel()
+
+	// Test cases for the fixes of the Stutter struct.
+	tcs := []struct {
+		name    string           // Name of the test case (for logging)
+		checker checkers.Stutter // Options of the checker
+		lines   []string         // Lines to check
+		fixed   []string         // Lines after the stutters are removed
+		comment string           // Comment in case of failure
+	}{
+		{
+			name:    "no stutter",
+			lines:   []string{"This line has no stutter."},
+			fixed:   []string{"This line has no stutter."},
+			comment: "Sequence with no stutter.",
+		},
+		{
+			name:    "Stutter",
+			lines:   []string{"This is the the end."},
+			fixed:   []string{"This is the end."},
+			comment: "Sequence with a stutter.",
+		},
+		{
+			name:    "Spacing",
+			lines:   []string{"|\tthe  the\tvalue  |  1  |"},
+			fixed:   []string{"|\tthe\tvalue  |  1  |"},
+			comment: "The spacing of the rest of the line is preserved.",
+		},
+		{
+			name:    "Multiple stutters",
+			lines:   []string{"  a  a\ta,  b b   c"},
+			fixed:   []string{"  a,  b   c"},
+			comment: "All stutters are removed in a single pass.",
+		},
+		{
+			name:    "Exception",
+			checker: checkers.Stutter{Exceptions: stuttering.Exceptions{{Phrase: "b"}}},
+			lines:   []string{"a a  b b"},
+			fixed:   []string{"a  b b"},
+			comment: "Exceptions are not fixed.",
+		},
+		{
+			name:    "Repeated phrase",
+			lines:   []string{"It is in the  in the\tbox, and can be can be. Done done."},
+			fixed:   []string{"It is in the\tbox, and can be. done."},
+			comment: "Phrases are removed along with the separator following them.",
+		},
+		{
+			name:    "Phrase longer than the length",
+			checker: checkers.Stutter{Length: 1},
+			lines:   []string{"in the in the"},
+			fixed:   []string{"in the in the"},
+			comment: "Only words are compared with a length of 1.",
+		},
+		{
+			name:    "Phrase exception",
+			checker: checkers.Stutter{Exceptions: stuttering.Exceptions{{Phrase: "in the"}}},
+			lines:   []string{"in the in the the end"},
+			fixed:   []string{"in the in the end"},
+			comment: "Exceptions match whole phrases.",
+		},
+		{
+			name:    "Normalized exception",
+			checker: checkers.Stutter{Exceptions: stuttering.Exceptions{{Phrase: "that"}}},
+			lines:   []string{"I know That that is the the truth."},
+			fixed:   []string{"I know That that is the truth."},
+			comment: "Exceptions are matched against the normalized phrase.",
+		},
+		{
+			name:    "Brackets",
+			lines:   []string{"(the the) end"},
+			fixed:   []string{"(the) end"},
+			comment: "The opening bracket is kept.",
+		},
+		{
+			name:    "Comments and strings",
+			checker: checkers.Stutter{Syntax: prose.Go},
+			lines:   []string{"func func() { // configure the", "\t// the server", `	x x := "a  a"`, "}"},
+			fixed:   []string{"func func() { // configure", "\t// the server", `	x x := "a"`, "}"},
+			comment: "Only the prose of source code is checked.",
+		},
+		{
+			name:    "Code between comments",
+			checker: checkers.Stutter{Syntax: prose.Go},
+			lines:   []string{"// configure the", "x := 1", "// the server"},
+			fixed:   []string{"// configure the", "x := 1", "// the server"},
+			comment: "Code interrupts the prose.",
+		},
+		{
+			name:    "Markdown",
+			checker: checkers.Stutter{Syntax: prose.Markdown},
+			lines:   []string{"Use `a a` and", "```", "and and", "```", "the the end"},
+			fixed:   []string{"Use `a a` and", "```", "and and", "```", "the end"},
+			comment: "Code blocks and inline code are not checked.",
+		},
+		{
+			name:    "Stutter across lines",
+			lines:   []string{"  configure the", "", "    the\tserver,"},
+			fixed:   []string{"  configure", "", "    the\tserver,"},
+			comment: "The first occurrence at the end of the previous non-blank line is removed.",
+		},
+		{
+			name:    "Stutter across lines with a sole word",
+			lines:   []string{"configure", "the", "the server"},
+			fixed:   []string{"configure", "the server"},
+			comment: "A line holding only the first occurrence is removed, instead of being left blank.",
+		},
+		{
+			name:    "Stutter across comment lines with a sole word",
+			checker: checkers.Stutter{Syntax: prose.Go},
+			lines:   []string{"// Set", "// the", "// the value", "x := 1 // the", "// the end"},
+			fixed:   []string{"// Set", "// the value", "x := 1 // ", "// the end"},
+			comment: "A comment line holding only the first occurrence is removed, unless code precedes it.",
+		},
+		{
+			name:    "Code before the prose",
+			checker: checkers.Stutter{Syntax: prose.Go},
+			lines:   []string{"// Set the", `var x = "the value"`},
+			fixed:   []string{"// Set the", `var x = "the value"`},
+			comment: "A string following code does not continue the comment of the previous line.",
+		},
+		{
+			name:    "Inline code before the prose",
+			checker: checkers.Stutter{Syntax: prose.Markdown},
+			lines:   []string{"Use the", "`x` the value"},
+			fixed:   []string{"Use the", "`x` the value"},
+			comment: "Prose following inline code does not continue the previous line.",
+		},
+		{
+			name:    "Comment followed by a string",
+			checker: checkers.Stutter{Syntax: prose.Python},
+			lines:   []string{"# Set the", `"""the value`, `"""`},
+			fixed:   []string{"# Set the", `"""the value`, `"""`},
+			comment: "A string does not continue the comment of the previous line.",
+		},
+		{
+			name:    "Heading followed by a paragraph",
+			checker: checkers.Stutter{Syntax: prose.Markdown},
+			lines:   []string{"# Setup", "", "Setup the server."},
+			fixed:   []string{"# Setup", "", "Setup the server."},
+			comment: "A heading is not continued by the next paragraph.",
+		},
+		{
+			name:    "Blank line in markup",
+			checker: checkers.Stutter{Syntax: prose.Markdown},
+			lines:   []string{"Configure the", "", "the server."},
+			fixed:   []string{"Configure the", "", "the server."},
+			comment: "A blank line ends the paragraph in markup.",
+		},
+		{
+			name:    "Headings and list items",
+			checker: checkers.Stutter{Syntax: prose.Markdown},
+			lines:   []string{"Setup", "=====", "Setup the", "- the item", "- item", "item the", "the end"},
+			fixed:   []string{"Setup", "=====", "Setup the", "- the item", "- item", "item", "the end"},
+			comment: "Headings, their underlines and list items are blocks of their own.",
+		},
+		{
+			name:    "Heading of a comment",
+			checker: checkers.Stutter{Syntax: prose.Go},
+			lines:   []string{"// # Setup", "//", "// Setup the server."},
+			fixed:   []string{"// # Setup", "//", "// Setup the server."},
+			comment: "A heading in a comment is not continued by the next line.",
+		},
+		{
+			name:    "Stutter across lines with punctuation",
+			lines:   []string{"Is it the", "the? Yes"},
+			fixed:   []string{"Is it", "the? Yes"},
+			comment: "The second occurrence is kept along with its punctuation.",
+		},
+		{
+			name:    "Exception across lines",
+			checker: checkers.Stutter{Exceptions: stuttering.Exceptions{{Phrase: "that"}}},
+			lines:   []string{"so that", "that is"},
+			fixed:   []string{"so that", "that is"},
+			comment: "Exceptions are not fixed.",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lines := toBytes(tc.lines)
+
+			fixed, conflicts := linter.Apply(lines, linter.Edits(tc.checker.Check(lines)))
+
+			require.Empty(t, conflicts, "conflicts: %s", tc.comment)
+
+			require.Equal(t, toBytes(tc.fixed), fixed, "fix failed: %s", tc.comment)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/idelchi/wslint/internal/linter"
+	"github.com/idelchi/wslint/pkg/prose"
+	"github.com/idelchi/wslint/pkg/typos"
+)
+
+// ErrTypo is returned when there is a misspelled word.
+var ErrTypo = errors.New("misspelled")
+
+// Typos keeps track of common misspellings.
+type Typos struct {
+	// Corrections maps the misspellings to their corrections. If nil, typos.Default applies.
+	Corrections typos.Corrections
+	// Words lists the words that are not reported, even if they are listed as misspellings.
+	Words typos.Words
+	// Syntax restricts the checks to the prose of the lines (e.g. the comments and strings of source code).
+	// If empty, the whole lines are checked.
+	Syntax prose.Syntax
+}
+
+// find returns the issues for the misspelled words in the text, starting at the given (0-based) offset of the
+// line at the given (0-based) row. Only the issues with an unambiguous correction carry an edit.
+func (t Typos) find(row, offset int, text []byte) (issues []linter.Issue) {
+	corrections := t.Corrections
+	if corrections == nil {
+		corrections = typos.Default()
+	}
+
+	for _, typo := range corrections.Find(string(text)) {
+		if t.Words.Contains(typo.Word) {
+			continue
+		}
+
+		issue := linter.Issue{
+			Line:      row + 1,
+			Column:    offset + typo.Index[0] + 1,
+			EndColumn: offset + typo.Index[1] + 1,
+			Message:   fmt.Sprintf("%v (%s)", ErrTypo, typo.Word),
+			Fix:       "replace with one of " + strings.Join(typo.Corrections, ", "),
+		}
+
+		if correction, ok := typo.Correction(); ok {
+			issue.Fix = fmt.Sprintf("replace with %q", correction)
+			issue.Edits = []linter.Edit{{
+				Line:      row + 1,
+				Column:    offset + typo.Index[0] + 1,
+				EndLine:   row + 1,
+				EndColumn: offset + typo.Index[1] + 1,
+				Text:      correction,
+			}}
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return
+}
+
+// Check checks the lines for misspelled words.
+func (t Typos) Check(lines [][]byte) (issues []linter.Issue) {
+	stream := t.Stream()
+
+	for _, line := range lines {
+		issues = append(issues, stream.Next(line)...)
+	}
+
+	return append(issues, stream.Close()...)
+}
+
+// Stream returns a stream checking for misspelled words line by line.
+func (t Typos) Stream() linter.Stream {
+	return &typosStream{typos: t, lexer: t.Syntax.Lexer()}
+}
+
+// typosStream checks for misspelled words line by line.
+type typosStream struct {
+	typos Typos
+	lexer prose.Lexer
+	row   int
+}
+
+// Next checks the prose of the line for misspelled words.
+func (s *typosStream) Next(line []byte) (issues []linter.Issue) {
+	defer func() { s.row++ }()
+
+	for _, span := range s.lexer.Next(line) {
+		issues = append(issues, s.typos.find(s.row, span[0], line[span[0]:span[1]])...)
+	}
+
+	return issues
+}
+
+// Held returns 0, as no lines are edited after they are checked.
+func (s *typosStream) Held() int {
+	return 0
+}
+
+// Close returns no issues, as all issues are found line by line.
+func (s *typosStream) Close() []linter.Issue {
+	return nil
+}
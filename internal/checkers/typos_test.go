@@ -0,0 +1,101 @@
+package checkers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/linter"
+	"github.com/idelchi/wslint/pkg/prose"
+	"github.com/idelchi/wslint/pkg/typos"
+)
+
+func TestTypos_Fix(t *testing.T) {
+	t.Parallel()
+
+	// Test cases for the fixes of the Typos struct.
+	tcs := []struct {
+		name    string         // Name of the test case (for logging)
+		checker checkers.Typos // Options of the checker
+		lines   []string       // Lines to check
+		fixed   []string       // Lines after the typos are corrected
+		issues  int            // Number of issues expected
+		comment string         // Comment in case of failure
+	}{
+		{
+			name:    "no typo",
+			lines:   []string{"This line is spelled correctly."},
+			fixed:   []string{"This line is spelled correctly."},
+			comment: "Sequence with no typo.",
+		},
+		{
+			name:    "Typo",
+			lines:   []string{"Teh value is recieved, TEH END."},
+			fixed:   []string{"The value is received, THE END."},
+			issues:  3,
+			comment: "The case of the word is preserved.",
+		},
+		{
+			name:    "Ambiguous typo",
+			lines:   []string{"Check wether it works."},
+			fixed:   []string{"Check wether it works."},
+			issues:  1,
+			comment: "Ambiguous typos are reported, but not fixed.",
+		},
+		{
+			name:    "Identifiers",
+			lines:   []string{"recieveData recieve_data teh2 \\nteh"},
+			fixed:   []string{"recieveData recieve_data teh2 \\nteh"},
+			comment: "Words that are part of identifiers are not checked.",
+		},
+		{
+			name:    "Allowed words",
+			checker: checkers.Typos{Words: typos.Words{"teh": {}}},
+			lines:   []string{"teh recieve"},
+			fixed:   []string{"teh receive"},
+			issues:  1,
+			comment: "Allowed words are not reported.",
+		},
+		{
+			name:    "Custom corrections",
+			checker: checkers.Typos{Corrections: typos.Corrections{"wslnit": {"wslint"}}},
+			lines:   []string{"Run wslnit, teh linter."},
+			fixed:   []string{"Run wslint, teh linter."},
+			issues:  1,
+			comment: "The custom corrections replace the bundled ones.",
+		},
+		{
+			name:    "Comments and strings",
+			checker: checkers.Typos{Syntax: prose.Go},
+			lines:   []string{"teh := recieve() // teh value", `fmt.Println("recieved")`},
+			fixed:   []string{"teh := recieve() // the value", `fmt.Println("received")`},
+			issues:  2,
+			comment: "Only the prose of source code is checked.",
+		},
+		{
+			name:    "Markdown",
+			checker: checkers.Typos{Syntax: prose.Markdown},
+			lines:   []string{"Use `teh` to", "```", "teh", "```", "recieve teh data"},
+			fixed:   []string{"Use `teh` to", "```", "teh", "```", "receive the data"},
+			issues:  2,
+			comment: "Code blocks and inline code are not checked.",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lines := toBytes(tc.lines)
+			issues := tc.checker.Check(lines)
+
+			require.Len(t, issues, tc.issues, "issues: %s", tc.comment)
+
+			fixed, conflicts := linter.Apply(lines, linter.Edits(issues))
+
+			require.Empty(t, conflicts, "conflicts: %s", tc.comment)
+
+			require.Equal(t, toBytes(tc.fixed), fixed, "fix failed: %s", tc.comment)
+		})
+	}
+}
@@ -1,25 +1,42 @@
 package checkers
 
 import (
+	"bytes"
 	"errors"
-	"fmt"
+	"strings"
 
+	"github.com/idelchi/wslint/internal/linter"
 	"github.com/idelchi/wslint/pkg/trailing"
 )
 
 // TODO(Idelchi): Returns trailing whitespace on one-line documents with one blank line
 // Need to restore the tests in this package.
 
-// ErrHasTrailing is returned when there is trailing whitespace.
-var ErrHasTrailing = errors.New("has trailing whitespace")
+var (
+	// ErrHasTrailing is returned when there is trailing whitespace.
+	ErrHasTrailing = errors.New("has trailing whitespace")
+	// ErrSpaceBeforeTab is returned when there is a space before a tab in the indentation.
+	ErrSpaceBeforeTab = errors.New("has a space before a tab in the indentation")
+)
+
+// tabWidth is the width of a tab, used to replace the indentation with tabs.
+const tabWidth = 8
 
 // Whitespace keeps track of trailing whitespaces.
-type Whitespace struct{}
+// Its options correspond to the whitespace rules of git, and default to only reporting trailing whitespaces.
+type Whitespace struct {
+	// IgnoreTrailing does not report trailing whitespaces (git's -blank-at-eol).
+	IgnoreTrailing bool
+	// SpaceBeforeTab reports spaces immediately before a tab in the indentation (git's space-before-tab).
+	SpaceBeforeTab bool
+	// CRAtEOL does not report a carriage return at the end of a line, as part of the line ending (git's cr-at-eol).
+	CRAtEOL bool
+}
 
-// Check identifies the lines that have trailing whitespaces.
-func (w Whitespace) Check(lines []string) (rows []int) {
+// Rows identifies the lines that have trailing whitespaces.
+func (w Whitespace) Rows(lines [][]byte) (rows []int) {
 	for i, line := range lines {
-		if trailing.Has(line) {
+		if start, _ := w.trailing(line); start >= 0 {
 			rows = append(rows, i)
 		}
 	}
@@ -27,35 +44,121 @@ func (w Whitespace) Check(lines []string) (rows []int) {
 	return
 }
 
-// assert evaluates the correctness of trailing whitespaces.
-// If rows is not empty, it means some lines have trailing whitespaces.
-func (w Whitespace) assert(rows []int) (errors []error) {
-	if len(rows) > 0 {
-		// TODO(Idelchi): Would be clearer to the user if the row values are incremented by 1.
-		errors = append(errors, fmt.Errorf("%w: on rows %v", ErrHasTrailing, rows))
+// trailing returns the (0-based) start and end of the trailing whitespaces of the line, or -1 if there are none.
+func (w Whitespace) trailing(line []byte) (start, end int) {
+	end = len(line)
+	if w.CRAtEOL && bytes.HasSuffix(line, []byte("\r")) {
+		end--
 	}
 
-	return
+	if w.IgnoreTrailing || !trailing.HasBytes(line[:end]) {
+		return -1, -1
+	}
+
+	return len(trailing.TrimBytes(line[:end])), end
 }
 
-// format removes trailing whitespaces from lines identified in rows.
-func (w Whitespace) format(lines []string, rows []int) []string {
-	for _, i := range rows {
-		lines[i] = trailing.Trim(lines[i])
+// find returns the issues of the line at the given (0-based) row, along with the edits fixing them.
+func (w Whitespace) find(row int, line []byte) (issues []linter.Issue) {
+	start, end := w.trailing(line)
+
+	if w.SpaceBeforeTab {
+		// Only the indentation of lines that are not all trailing whitespace is considered.
+		content := line
+		if start >= 0 {
+			content = line[:start]
+		}
+
+		if issue, ok := w.spaceBeforeTab(row, content); ok {
+			issues = append(issues, issue)
+		}
+	}
+
+	if start >= 0 {
+		issues = append(issues, linter.Issue{
+			Line:      row + 1,
+			Column:    start + 1,
+			EndColumn: end + 1,
+			Message:   ErrHasTrailing.Error(),
+			Fix:       "remove the trailing whitespace",
+			Edits:     []linter.Edit{{Line: row + 1, Column: start + 1, EndLine: row + 1, EndColumn: end + 1}},
+		})
 	}
 
-	return lines
+	return issues
 }
 
-// Format checks the lines for trailing whitespaces, asserts any errors,
-// and then formats the lines to remove those whitespaces.
-func (w Whitespace) Format(lines []string) ([]string, []error) {
-	rows := w.Check(lines)
-	errs := w.assert(rows)
+// spaceBeforeTab returns the issue for spaces before a tab in the indentation of the line at the given
+// (0-based) row, spanning from the first such space to the last tab of the indentation. Its edit replaces
+// the indentation up to the last tab with tabs, which renders the same.
+func (w Whitespace) spaceBeforeTab(row int, line []byte) (linter.Issue, bool) {
+	indentation := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+
+	first := bytes.Index(indentation, []byte(" \t"))
+	if first < 0 {
+		return linter.Issue{}, false
+	}
 
-	if len(errs) == 0 {
-		return lines, errs
+	// The start of the run of spaces before the tab.
+	for first > 0 && indentation[first-1] == ' ' {
+		first--
 	}
 
-	return w.format(lines, rows), errs
+	last := bytes.LastIndexByte(indentation, '\t') + 1
+
+	width := 0
+
+	for _, c := range indentation[:last] {
+		if c == '\t' {
+			width += tabWidth - width%tabWidth
+		} else {
+			width++
+		}
+	}
+
+	return linter.Issue{
+		Line:      row + 1,
+		Column:    first + 1,
+		EndColumn: last + 1,
+		Message:   ErrSpaceBeforeTab.Error(),
+		Fix:       "indent with tabs only",
+		Edits:     []linter.Edit{{Line: row + 1, Column: 1, EndLine: row + 1, EndColumn: last + 1, Text: strings.Repeat("\t", width/tabWidth)}},
+	}, true
+}
+
+// Check checks the lines for trailing whitespaces, and returns an issue for each line that has them.
+func (w Whitespace) Check(lines [][]byte) (issues []linter.Issue) {
+	for row, line := range lines {
+		issues = append(issues, w.find(row, line)...)
+	}
+
+	return
+}
+
+// Stream returns a stream checking for trailing whitespaces line by line.
+func (w Whitespace) Stream() linter.Stream {
+	return &whitespaceStream{whitespace: w}
+}
+
+// whitespaceStream checks for trailing whitespaces line by line.
+type whitespaceStream struct {
+	whitespace Whitespace
+	row        int
+}
+
+// Next checks the line for trailing whitespaces.
+func (s *whitespaceStream) Next(line []byte) []linter.Issue {
+	defer func() { s.row++ }()
+
+	return s.whitespace.find(s.row, line)
+}
+
+// Held returns 0, as no lines are edited after they are checked.
+func (s *whitespaceStream) Held() int {
+	return 0
+}
+
+// Close returns no issues, as all issues are found line by line.
+func (s *whitespaceStream) Close() []linter.Issue {
+	return nil
 }
@@ -6,12 +6,23 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/linter"
 )
 
+// toBytes converts a slice of strings to a slice of byte slices.
+func toBytes(lines []string) [][]byte {
+	converted := make([][]byte, len(lines))
+	for i, line := range lines {
+		converted[i] = []byte(line)
+	}
+
+	return converted
+}
+
 // Test the Whitespace struct.
 // Test-sequence is:
 // 1. Create a Whitespace struct.
-// 2. Call the Format method.
+// 2. Call the Check method.
 // 3. Check the results.
 func TestWhiteSpace(t *testing.T) {
 	t.Parallel()
@@ -21,6 +32,7 @@ func TestWhiteSpace(t *testing.T) {
 		name    string   // Name of the test case (for logging)
 		lines   []string // List of lines to check
 		rows    []int    // List of rows that should have trailing whitespace
+		columns []int    // Columns where the trailing whitespace starts
 		stop    int      // Stop row
 		err     error    // Error that should be returned
 		comment string   // Comment in case of failure
@@ -57,6 +69,7 @@ func TestWhiteSpace(t *testing.T) {
 				"Here too. \t    ",
 			},
 			rows:    []int{2, 3, 4},
+			columns: []int{24, 22, 10},
 			err:     checkers.ErrHasTrailing,
 			comment: "Sequence with trailing whitespace.",
 		},
@@ -65,21 +78,25 @@ func TestWhiteSpace(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			linter := checkers.Whitespace{}
+			checker := checkers.Whitespace{}
 
-			rows := linter.Check(tc.lines)
+			rows := checker.Rows(toBytes(tc.lines))
 
 			// offset each line by 1
 			for i := range rows {
 				rows[i]++
 			}
 
-			_, errs := linter.Format(tc.lines)
+			issues := checker.Check(toBytes(tc.lines))
 
 			require.Equal(t, tc.rows, rows, "rows failed: %s", tc.comment)
+			require.Len(t, issues, len(tc.rows), "issues failed: %s", tc.comment)
 
-			for _, err := range errs {
-				require.ErrorIs(t, err, tc.err, "err failed: %s", tc.comment)
+			for i, issue := range issues {
+				require.Equal(t, tc.rows[i], issue.Line, "line failed: %s", tc.comment)
+				require.Equal(t, tc.columns[i], issue.Column, "column failed: %s", tc.comment)
+				require.Equal(t, len(tc.lines[tc.rows[i]-1])+1, issue.EndColumn, "end column failed: %s", tc.comment)
+				require.Equal(t, tc.err.Error(), issue.Message, "message failed: %s", tc.comment)
 			}
 		})
 	}
@@ -90,10 +107,11 @@ func TestWhiteSpace_Fix(t *testing.T) {
 
 	// Test cases for the whitespace.Fix() method.
 	tcs := []struct {
-		name    string // Name of the test case (for logging)
-		line    string // Line to check
-		fixed   string // Line after whitespace is removed
-		comment string // Comment in case of failure
+		name    string              // Name of the test case (for logging)
+		checker checkers.Whitespace // Options of the checker
+		line    string              // Line to check
+		fixed   string              // Line after whitespace is removed
+		comment string              // Comment in case of failure
 	}{
 		{
 			name:    "no trailing whitespace",
@@ -119,16 +137,53 @@ func TestWhiteSpace_Fix(t *testing.T) {
 			fixed:   "This line has mixed trailing tabs and spaces.",
 			comment: "Sequence with trailing tabs and spaces.",
 		},
+		{
+			name:    "Trailing carriage return",
+			line:    "This line ends with a carriage return. \r",
+			fixed:   "This line ends with a carriage return.",
+			comment: "Carriage returns are whitespace by default.",
+		},
+		{
+			name:    "Carriage return at the end of the line",
+			checker: checkers.Whitespace{CRAtEOL: true},
+			line:    "This line ends with a carriage return. \r",
+			fixed:   "This line ends with a carriage return.\r",
+			comment: "Carriage returns are kept with cr-at-eol.",
+		},
+		{
+			name:    "Space before tab",
+			checker: checkers.Whitespace{SpaceBeforeTab: true},
+			line:    "\t  \t \tindented  ",
+			fixed:   "\t\t\tindented",
+			comment: "Spaces before tabs in the indentation are replaced by tabs.",
+		},
+		{
+			name:    "Space before tab only",
+			checker: checkers.Whitespace{SpaceBeforeTab: true, IgnoreTrailing: true},
+			line:    "    \tindented \t ",
+			fixed:   "\tindented \t ",
+			comment: "Trailing whitespace is kept with -blank-at-eol.",
+		},
+		{
+			name:    "Space before tab on a blank line",
+			checker: checkers.Whitespace{SpaceBeforeTab: true},
+			line:    " \t",
+			fixed:   "",
+			comment: "Blank lines are trailing whitespace only.",
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			linter := checkers.Whitespace{}
+			checker := tc.checker
+			lines := toBytes([]string{tc.line})
+
+			fixed, conflicts := linter.Apply(lines, linter.Edits(checker.Check(lines)))
 
-			fixed, _ := linter.Format([]string{tc.line})
+			require.Empty(t, conflicts, "conflicts: %s", tc.comment)
 
-			require.Equal(t, tc.fixed, fixed[0], "fix failed: %s", tc.comment)
+			require.Equal(t, tc.fixed, string(fixed[0]), "fix failed: %s", tc.comment)
 		})
 	}
 }
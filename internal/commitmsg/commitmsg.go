@@ -0,0 +1,167 @@
+// Package commitmsg lints git commit message files, as passed to the commit-msg hook.
+//
+// Only the message itself is checked: comment lines (starting with "#") are ignored, as is everything
+// from the scissors line on (which git adds above the diff of a verbose commit). The comment lines and
+// blank lines at the end of the message, such as the instructions added by git, are not part of the message,
+// so that the message is expected to end with a single newline right before them.
+package commitmsg
+
+import (
+	"bytes"
+
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+const (
+	// Comment is the prefix of comment lines.
+	Comment = "#"
+	// Scissors is the line below which git ignores everything.
+	Scissors = Comment + " ------------------------ >8 ------------------------"
+)
+
+// Message is the message part of a commit message file.
+type Message struct {
+	// Lines are the lines of the message, without the comment lines.
+	Lines [][]byte
+	// rows are the (0-based) rows in the file of the lines of the message. If the message is followed
+	// by comments, the last line of the message is the remainder after its final newline, which has no row.
+	rows []int
+}
+
+// Parse returns the message part of the lines of a commit message file.
+func Parse(lines [][]byte) Message {
+	end := len(lines)
+
+	for row, line := range lines {
+		if string(line) == Scissors {
+			end = row
+
+			break
+		}
+	}
+
+	// Strip the trailing comments (and the scissors section), along with the blank lines around them.
+	trailing := end
+
+	for row := end - 1; row >= 0 && (isComment(lines[row]) || isBlank(lines[row])); row-- {
+		if isComment(lines[row]) {
+			trailing = row
+		}
+	}
+
+	for trailing < len(lines) && trailing > 0 && isBlank(lines[trailing-1]) {
+		trailing--
+	}
+
+	var message Message
+
+	for row, line := range lines[:trailing] {
+		if !isComment(line) {
+			message.Lines = append(message.Lines, line)
+			message.rows = append(message.rows, row)
+		}
+	}
+
+	// Unless the message extends to the end of the file, it ends with the newline of its last line.
+	if trailing < len(lines) {
+		message.Lines = append(message.Lines, nil)
+	}
+
+	return message
+}
+
+// Issues translates the issues found in the lines of the message into issues of the file.
+func (m Message) Issues(issues []linter.Issue) []linter.Issue {
+	translated := make([]linter.Issue, 0, len(issues))
+
+	for _, issue := range issues {
+		issue.Line, _ = m.start(issue.Line, issue.Column)
+
+		edits := make([]linter.Edit, 0, len(issue.Edits))
+		for _, edit := range issue.Edits {
+			edits = append(edits, m.edit(edit))
+		}
+
+		issue.Edits = edits
+		translated = append(translated, issue)
+	}
+
+	return translated
+}
+
+// edit translates an edit of the lines of the message into an edit of the file.
+func (m Message) edit(edit linter.Edit) linter.Edit {
+	empty := edit.Line == edit.EndLine && edit.Column == edit.EndColumn
+
+	edit.Line, edit.Column = m.start(edit.Line, edit.Column)
+
+	if empty {
+		edit.EndLine, edit.EndColumn = edit.Line, edit.Column
+	} else {
+		edit.EndLine, edit.EndColumn = m.end(edit.EndLine, edit.EndColumn)
+	}
+
+	return edit
+}
+
+// start translates the position of the start of a range into the file. The start of a line is the start
+// of the same line in the file, excluding any comment lines before it.
+func (m Message) start(line, column int) (int, int) {
+	if line > len(m.rows) {
+		return m.after(line - 1), 1
+	}
+
+	return m.rows[line-1] + 1, column
+}
+
+// end translates the position of the (exclusive) end of a range into the file. The start of a line is
+// the position right after the newline of the previous line, including any comment lines after it.
+func (m Message) end(line, column int) (int, int) {
+	if column == 1 {
+		return m.after(line - 1), 1
+	}
+
+	return m.rows[line-1] + 1, column
+}
+
+// after returns the line in the file following the given line of the message (or the first line for 0).
+func (m Message) after(line int) int {
+	if line == 0 {
+		return 1
+	}
+
+	return m.rows[line-1] + 2 //nolint:mnd // The line after, 1-based.
+}
+
+// Checker checks the message part of a commit message file with the wrapped checker.
+type Checker struct {
+	Checker linter.Checker
+}
+
+// Check checks the message in the lines of a commit message file.
+func (c Checker) Check(lines [][]byte) []linter.Issue {
+	message := Parse(lines)
+
+	return message.Issues(c.Checker.Check(message.Lines))
+}
+
+// Wrap wraps each checker of the pipeline to check the message part of a commit message file only.
+func Wrap(checkers []linter.NamedChecker) []linter.NamedChecker {
+	wrapped := make([]linter.NamedChecker, 0, len(checkers))
+
+	for _, checker := range checkers {
+		wrapped = append(wrapped, linter.NamedChecker{Name: checker.Name, Checker: Checker{Checker: checker.Checker}})
+	}
+
+	return wrapped
+}
+
+// isComment returns true if the line is a comment line.
+func isComment(line []byte) bool {
+	return bytes.HasPrefix(line, []byte(Comment))
+}
+
+// isBlank returns true if the line contains only whitespace.
+func isBlank(line []byte) bool {
+	return len(bytes.TrimSpace(line)) == 0
+}
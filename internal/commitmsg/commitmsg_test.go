@@ -0,0 +1,97 @@
+package commitmsg_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/commitmsg"
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string // Name of the test case (for logging)
+		content string // Content of the commit message file
+		fixed   string // Content expected after fixing
+		lines   []int  // Lines of the issues expected
+	}{
+		{
+			name:    "clean",
+			content: "Subject\n\nBody\n",
+			fixed:   "Subject\n\nBody\n",
+		},
+		{
+			name:    "clean with instructions",
+			content: "Subject\n\n# Please enter the commit message.\n#\n",
+			fixed:   "Subject\n\n# Please enter the commit message.\n#\n",
+		},
+		{
+			name:    "empty message",
+			content: "\n# Please enter the commit message.\n",
+			fixed:   "\n# Please enter the commit message.\n",
+		},
+		{
+			name:    "trailing whitespace",
+			content: "Subject \n\nBody\t\n# comment \n",
+			fixed:   "Subject\n\nBody\n# comment \n",
+			lines:   []int{1, 3},
+		},
+		{
+			name:    "stutter",
+			content: "Fix the the bug\n",
+			fixed:   "Fix the bug\n",
+			lines:   []int{1},
+		},
+		{
+			name:    "comment lines within the message",
+			content: "Subject\n# comment\nBody \n\n\n",
+			fixed:   "Subject\n# comment\nBody\n",
+			lines:   []int{3, 4, 5},
+		},
+		{
+			name:    "missing newline",
+			content: "Subject",
+			fixed:   "Subject\n",
+			lines:   []int{1},
+		},
+		{
+			name:    "scissors section",
+			content: "Subject \n# ------------------------ >8 ------------------------\ndiff  \n\n\n",
+			fixed:   "Subject\n# ------------------------ >8 ------------------------\ndiff  \n\n\n",
+			lines:   []int{1},
+		},
+	}
+
+	pipeline := commitmsg.Wrap([]linter.NamedChecker{
+		{Name: "stutter", Checker: checkers.Stutter{}},
+		{Name: "whitespace", Checker: checkers.Whitespace{}},
+		{Name: "blanks", Checker: checkers.Blanks{}},
+	})
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lint := linter.New(tc.name, pipeline)
+			fixed, err := lint.Format(bytes.Split([]byte(tc.content), []byte("\n")))
+			require.NoError(t, err)
+
+			require.Equal(t, tc.fixed, string(bytes.Join(fixed, []byte("\n"))))
+
+			var lines []int
+
+			for _, checker := range pipeline {
+				for _, issue := range lint.Issues[checker.Name] {
+					lines = append(lines, issue.Line)
+				}
+			}
+
+			require.ElementsMatch(t, tc.lines, lines)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Package config reads the configuration file of wslint.
+//
+// The configuration file is a YAML file, by default ".wslint.yaml" in the current directory:
+//
+//	# The file listing the stutter exceptions, relative to the configuration file.
+//	stutter-exceptions: settings/stutters
+//	# The file listing the words not reported as misspelled, relative to the configuration file.
+//	typos-words: settings/project-words
+//	# Rules reporting the matches of a regular expression, each under its id.
+//	rules:
+//	  - id: no-console-log
+//	    message: leftover debug output
+//	    regex: console\.log\(
+//	    include: ["**/*.js"]
+//	    exclude: ["vendor/**"]
+//	    severity: warning          # "error" (default) or "warning"
+//	    replacement: "logger.debug("  # optional, with $1 or ${name} expanded to the submatches
+//	# Shorthand for rules with only an id (name) and a regular expression (pattern).
+//	forbidden:
+//	  - name: fixme-now
+//	    pattern: FIXME-NOW
+//	# Headers the files must start with, in the comment syntax of their type (the first applying is required).
+//	headers:
+//	  - include: ["**/*.go"]
+//	    comment: "// "
+//	    lines: 10                  # the header must start within the first lines (default 10)
+//	    template: |
+//	      SPDX-License-Identifier: MIT
+//	      Copyright {{year}} Acme
+//	  - include: ["**/*.xml"]
+//	    start: "<!--"
+//	    end: "-->"
+//	    template: "SPDX-License-Identifier: MIT"
+//
+// Unknown keys are rejected, to catch typos.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Default is the configuration file used if none is given explicitly.
+const Default = ".wslint.yaml"
+
+// ErrInvalid is returned when the configuration file cannot be parsed.
+var ErrInvalid = errors.New("invalid configuration")
+
+// Config is the content of the configuration file.
+type Config struct {
+	// StutterExceptions is the path of the file listing the stutter exceptions.
+	// Load resolves it relative to the directory of the configuration file.
+	StutterExceptions string `yaml:"stutter-exceptions"`
+	// TyposWords is the path of the file listing the words not reported as misspelled.
+	// Load resolves it relative to the directory of the configuration file.
+	TyposWords string `yaml:"typos-words"`
+	// Rules lists the user-defined rules.
+	Rules []Rule `yaml:"rules"`
+	// Forbidden lists the regular expressions that must not match the content, as a shorthand for rules.
+	Forbidden []Forbidden `yaml:"forbidden"`
+	// Headers lists the headers the files must start with, e.g. one for each type of file.
+	Headers []Header `yaml:"headers"`
+}
+
+// Rule is a regular expression that must not match the content, reported under its id.
+type Rule struct {
+	ID      string   `yaml:"id"`
+	Message string   `yaml:"message"`
+	Regex   string   `yaml:"regex"`
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+	// Severity is "error" (the default) or "warning".
+	Severity string `yaml:"severity"`
+	// Replacement is the template the matches are replaced with, if set.
+	Replacement *string `yaml:"replacement"`
+}
+
+// Forbidden is a regular expression that must not match the content, reported under its name.
+type Forbidden struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"`
+}
+
+// Header is the header (e.g. a license notice) the files it applies to must start with.
+type Header struct {
+	// Template is the text of the header, with "{{year}}" standing for the year.
+	Template string `yaml:"template"`
+	// Comment prefixes each line of the template, while Start and End enclose it.
+	Comment string `yaml:"comment"`
+	Start   string `yaml:"start"`
+	End     string `yaml:"end"`
+	// Lines is the number of lines the header must start within.
+	Lines   int      `yaml:"lines"`
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// AllRules returns the rules, preceded by the forbidden patterns.
+func (c Config) AllRules() []Rule {
+	rules := make([]Rule, 0, len(c.Forbidden)+len(c.Rules))
+
+	for _, forbidden := range c.Forbidden {
+		rules = append(rules, Rule{ID: forbidden.Name, Regex: forbidden.Pattern})
+	}
+
+	return append(rules, c.Rules...)
+}
+
+// Load reads the configuration file at path.
+// If the file does not exist and optional is set, it returns an empty configuration.
+func Load(path string, optional bool) (Config, error) {
+	var cfg Config
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+
+		return cfg, fmt.Errorf("reading configuration: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return cfg, fmt.Errorf("%w: %q: %w", ErrInvalid, path, err)
+	}
+
+	for _, file := range []*string{&cfg.StutterExceptions, &cfg.TyposWords} {
+		if *file != "" && !filepath.IsAbs(*file) {
+			*file = filepath.Join(filepath.Dir(path), *file)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,117 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/config"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string        // Name of the test case (for logging)
+		content  *string       // Content of the configuration file, nil if it does not exist
+		optional bool          // Whether the configuration file is optional
+		want     config.Config // Expected configuration, with paths relative to the configuration directory
+		err      bool          // Whether an error is expected
+	}{
+		{
+			name:     "missing optional file",
+			optional: true,
+		},
+		{
+			name: "missing file",
+			err:  true,
+		},
+		{
+			name:    "empty file",
+			content: ptr(""),
+		},
+		{
+			name:    "relative exceptions",
+			content: ptr("stutter-exceptions: settings/stutters\n"),
+			want:    config.Config{StutterExceptions: "settings/stutters"},
+		},
+		{
+			name:    "forbidden patterns",
+			content: ptr("forbidden:\n  - name: console-log\n    pattern: console\\.log\n"),
+			want:    config.Config{Forbidden: []config.Forbidden{{Name: "console-log", Pattern: `console\.log`}}},
+		},
+		{
+			name: "rules",
+			content: ptr("rules:\n  - id: no-console-log\n    message: debug output\n    regex: console\\.log\n" +
+				"    include: [\"**/*.js\"]\n    severity: warning\n    replacement: \"\"\n"),
+			want: config.Config{Rules: []config.Rule{{
+				ID: "no-console-log", Message: "debug output", Regex: `console\.log`, Include: []string{"**/*.js"},
+				Severity: "warning", Replacement: ptr(""),
+			}}},
+		},
+		{
+			name: "headers",
+			content: ptr("headers:\n  - include: [\"**/*.go\"]\n    comment: \"// \"\n    lines: 5\n" +
+				"    template: |\n      SPDX-License-Identifier: MIT\n      Copyright {{year}} Acme\n"),
+			want: config.Config{Headers: []config.Header{{
+				Template: "SPDX-License-Identifier: MIT\nCopyright {{year}} Acme\n", Comment: "// ", Lines: 5,
+				Include: []string{"**/*.go"},
+			}}},
+		},
+		{
+			name:    "unknown key",
+			content: ptr("stutter-exception: settings/stutters\n"),
+			err:     true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, config.Default)
+
+			if tc.content != nil {
+				require.NoError(t, os.WriteFile(path, []byte(*tc.content), 0o600))
+			}
+
+			cfg, err := config.Load(path, tc.optional)
+			if tc.err {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.want.StutterExceptions != "" {
+				tc.want.StutterExceptions = filepath.Join(dir, tc.want.StutterExceptions)
+			}
+
+			require.Equal(t, tc.want, cfg)
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestConfig_AllRules(t *testing.T) {
+	t.Parallel()
+
+	replacement := "logger.debug("
+
+	cfg := config.Config{
+		Rules:     []config.Rule{{ID: "no-console-log", Regex: `console\.log\(`, Replacement: &replacement}},
+		Forbidden: []config.Forbidden{{Name: "fixme-now", Pattern: "FIXME-NOW"}},
+	}
+
+	require.Equal(t, []config.Rule{
+		{ID: "fixme-now", Regex: "FIXME-NOW"},
+		{ID: "no-console-log", Regex: `console\.log\(`, Replacement: &replacement},
+	}, cfg.AllRules())
+}
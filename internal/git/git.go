@@ -0,0 +1,264 @@
+// Package git reads and writes the content of a git repository through the git command line, which works
+// fully offline. It provides the few plumbing operations needed to lint staged content and the content of
+// commits: listing the staged files and the files of a tree, reading and writing blobs, updating the index
+// and merging fixes into the working tree.
+//
+// The git commands inherit the environment, so that within hooks they operate on the index
+// selected by git (e.g. GIT_INDEX_FILE for partial commits).
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrGit is returned when a git command fails.
+	ErrGit = errors.New("git command failed")
+	// ErrNotRepository is returned when a directory is not within a git repository.
+	ErrNotRepository = errors.New("not a git repository")
+	// ErrUnknownRevision is returned when a revision does not name a commit or tree.
+	ErrUnknownRevision = errors.New("unknown revision")
+)
+
+// Regular file modes, as stored in the index. Other modes (symbolic links, submodules) are not linted.
+const (
+	ModeFile       = "100644"
+	ModeExecutable = "100755"
+)
+
+// Repo is a git repository.
+type Repo struct {
+	// Root is the top-level directory of the working tree, or the git directory of a bare repository.
+	Root string
+	// Bare is true if the repository has no working tree (or was opened from within its git directory).
+	Bare bool
+}
+
+// Entry is an entry of the index.
+type Entry struct {
+	Mode string
+	Hash string
+	// Path is the path of the file, relative to the root of the repository and slash-separated.
+	Path string
+}
+
+// Regular returns true if the entry is a regular (possibly executable) file.
+func (e Entry) Regular() bool {
+	return e.Mode == ModeFile || e.Mode == ModeExecutable
+}
+
+// Open returns the repository containing the directory.
+// It returns ErrNotRepository if there is none, and ErrGit if git cannot be run.
+func Open(dir string) (Repo, error) {
+	out, err := run(dir, nil, "rev-parse", "--show-toplevel")
+	if err == nil {
+		return Repo{Root: filepath.FromSlash(strings.TrimSpace(string(out)))}, nil
+	}
+
+	// Bare repositories, and git directories, have no top-level directory.
+	if out, err := run(dir, nil, "rev-parse", "--absolute-git-dir"); err == nil {
+		return Repo{Root: filepath.FromSlash(strings.TrimSpace(string(out))), Bare: true}, nil
+	}
+
+	if errors.Is(err, exec.ErrNotFound) {
+		return Repo{}, err
+	}
+
+	return Repo{}, fmt.Errorf("%w: %q: %v", ErrNotRepository, dir, err) //nolint:errorlint // Not an error of git.
+}
+
+// HooksDir returns the directory holding the hooks, honouring core.hooksPath.
+func (r Repo) HooksDir() (string, error) {
+	return r.GitPath("hooks")
+}
+
+// GitPath returns the path of the file or directory with the given name within the git directory,
+// as resolved by git (e.g. "info/attributes").
+func (r Repo) GitPath(name string) (string, error) {
+	out, err := r.git(nil, "rev-parse", "--git-path", name)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.FromSlash(strings.TrimSpace(string(out)))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.Root, path)
+	}
+
+	return path, nil
+}
+
+// Staged returns the index entries of the files added, copied, modified or renamed in the index,
+// compared to HEAD (or to the empty tree before the first commit).
+func (r Repo) Staged() ([]Entry, error) {
+	out, err := r.git(nil, "diff", "--cached", "--name-only", "--no-renames", "-z", "--diff-filter=ACMR")
+	if err != nil {
+		return nil, err
+	}
+
+	staged := make(map[string]bool)
+	for _, path := range split(out) {
+		staged[path] = true
+	}
+
+	if len(staged) == 0 {
+		return nil, nil
+	}
+
+	// The whole index is listed and filtered, as passing the paths as pathspecs would expand wildcards
+	// in their names, and could exceed the limits of the command line.
+	out, err = r.git(nil, "ls-files", "--stage", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+
+	for _, record := range split(out) {
+		// <mode> SP <hash> SP <stage> TAB <path>
+		info, path, ok := strings.Cut(record, "\t")
+		if fields := strings.Fields(info); ok && len(fields) == 3 && staged[path] {
+			entries = append(entries, Entry{Mode: fields[0], Hash: fields[1], Path: path})
+		}
+	}
+
+	return entries, nil
+}
+
+// Tree returns the entries of the files in the tree of the commit (or tree) named by rev, recursively.
+// It returns ErrUnknownRevision if rev names no commit or tree.
+// The hash of an entry is the hash of its blob.
+func (r Repo) Tree(rev string) ([]Entry, error) {
+	tree, err := r.tree(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := r.git(nil, "ls-tree", "-r", "-z", "--full-tree", tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+
+	for _, record := range split(out) {
+		// <mode> SP <type> SP <hash> TAB <path>
+		info, path, ok := strings.Cut(record, "\t")
+		if fields := strings.Fields(info); ok && len(fields) == 3 && fields[1] == "blob" {
+			entries = append(entries, Entry{Mode: fields[0], Hash: fields[2], Path: path})
+		}
+	}
+
+	return entries, nil
+}
+
+// tree returns the hash of the tree of the commit (or tree) named by rev.
+func (r Repo) tree(rev string) (string, error) {
+	out, err := r.git(nil, "rev-parse", "--verify", "--quiet", "--end-of-options", rev+"^{tree}")
+	if err != nil {
+		return "", fmt.Errorf("%w: %q does not name a commit or tree", ErrUnknownRevision, rev)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ReadBlob returns the content of a blob.
+func (r Repo) ReadBlob(hash string) ([]byte, error) {
+	return r.git(nil, "cat-file", "blob", hash)
+}
+
+// WriteBlob writes the content into the object database and returns the hash of the blob.
+func (r Repo) WriteBlob(content []byte) (string, error) {
+	out, err := r.git(content, "hash-object", "-w", "--no-filters", "--stdin")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Stage updates the index entry of a path to point to the given blob, keeping the working tree untouched.
+func (r Repo) Stage(entry Entry) error {
+	_, err := r.git(nil, "update-index", "--cacheinfo", fmt.Sprintf("%s,%s,%s", entry.Mode, entry.Hash, entry.Path))
+
+	return err
+}
+
+// Merge performs a three-way merge of the changes from base to other into current, as done by git merge-file.
+// It returns the merged content, and whether the changes conflicted (in which case the content is unusable).
+func (r Repo) Merge(current, base, other []byte) (merged []byte, conflicts bool, err error) {
+	dir, err := os.MkdirTemp("", "wslint-merge-*")
+	if err != nil {
+		return nil, false, fmt.Errorf("creating temporary directory: %w", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	names := []string{"current", "base", "other"}
+
+	for i, content := range [][]byte{current, base, other} {
+		names[i] = filepath.Join(dir, names[i])
+
+		if err := os.WriteFile(names[i], content, 0o600); err != nil {
+			return nil, false, fmt.Errorf("writing temporary file: %w", err)
+		}
+	}
+
+	merged, err = r.git(nil, append([]string{"merge-file", "-p", "-q"}, names...)...)
+
+	// A positive exit code is the number of conflicts.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+		return nil, true, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return merged, false, nil
+}
+
+// git runs a git command in the root of the repository, with the given standard input.
+func (r Repo) git(stdin []byte, args ...string) ([]byte, error) {
+	return run(r.Root, stdin, args...)
+}
+
+// run runs a git command in the directory, with the given standard input, and returns its standard output.
+func run(dir string, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return out, fmt.Errorf("%w: git %s: %w: %s", ErrGit, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
+// split splits NUL-terminated records.
+func split(out []byte) []string {
+	var records []string
+
+	for _, record := range bytes.Split(out, []byte{0}) {
+		if len(record) > 0 {
+			records = append(records, string(record))
+		}
+	}
+
+	return records
+}
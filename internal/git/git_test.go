@@ -0,0 +1,183 @@
+package git_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/git"
+)
+
+// repository creates a git repository in a temporary directory, with the files written and staged.
+func repository(t *testing.T, files map[string]string) git.Repo {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := t.TempDir()
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "wslint@example.com"},
+		{"config", "user.name", "wslint"},
+	} {
+		require.NoError(t, exec.Command("git", append([]string{"-C", dir}, args...)...).Run())
+	}
+
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+		require.NoError(t, exec.Command("git", "-C", dir, "add", name).Run())
+	}
+
+	repo, err := git.Open(dir)
+	require.NoError(t, err)
+
+	return repo
+}
+
+func TestRepo_Staged(t *testing.T) {
+	t.Parallel()
+
+	repo := repository(t, map[string]string{"a.txt": "a \n", "dir/b.txt": "b\n"})
+
+	entries, err := repo.Staged()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, "a.txt", entries[0].Path)
+	require.Equal(t, "dir/b.txt", entries[1].Path)
+	require.True(t, entries[0].Regular())
+
+	content, err := repo.ReadBlob(entries[0].Hash)
+	require.NoError(t, err)
+	require.Equal(t, "a \n", string(content))
+
+	// Staging a new blob changes the index only.
+	entries[0].Hash, err = repo.WriteBlob([]byte("a\n"))
+	require.NoError(t, err)
+	require.NoError(t, repo.Stage(entries[0]))
+
+	staged, err := repo.Staged()
+	require.NoError(t, err)
+	require.Equal(t, entries, staged)
+
+	worktree, err := os.ReadFile(filepath.Join(repo.Root, "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "a \n", string(worktree))
+
+	hooks, err := repo.HooksDir()
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(repo.Root, ".git", "hooks"), hooks)
+}
+
+func TestOpen_NotRepository(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	_, err := git.Open(t.TempDir())
+	require.ErrorIs(t, err, git.ErrNotRepository)
+	require.NotErrorIs(t, err, git.ErrGit)
+}
+
+func TestRepo_StagedWildcards(t *testing.T) {
+	t.Parallel()
+
+	repo := repository(t, map[string]string{"a.txt": "a\n"})
+	require.NoError(t, exec.Command("git", "-C", repo.Root, "commit", "-q", "-m", "initial").Run())
+
+	// The name of the staged file is a pattern matching the committed file.
+	require.NoError(t, os.WriteFile(filepath.Join(repo.Root, "[ab].txt"), []byte("b\n"), 0o600))
+	require.NoError(t, exec.Command("git", "-C", repo.Root, "--literal-pathspecs", "add", "[ab].txt").Run())
+
+	entries, err := repo.Staged()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "[ab].txt", entries[0].Path)
+}
+
+func TestRepo_Merge(t *testing.T) {
+	t.Parallel()
+
+	repo := repository(t, nil)
+
+	tcs := []struct {
+		name      string // Name of the test case (for logging)
+		current   string // Content of the working tree
+		base      string // Staged content
+		other     string // Fixed staged content
+		merged    string // Content expected after merging
+		conflicts bool   // Whether the merge is expected to conflict
+	}{
+		{
+			name:    "unstaged hunk preserved",
+			current: "a \nb\nc\nd\ne\nchanged\n",
+			base:    "a \nb\nc\nd\ne\nf\n",
+			other:   "a\nb\nc\nd\ne\nf\n",
+			merged:  "a\nb\nc\nd\ne\nchanged\n",
+		},
+		{
+			name:      "unstaged change of a fixed line",
+			current:   "a changed\n",
+			base:      "a \n",
+			other:     "a\n",
+			conflicts: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, conflicts, err := repo.Merge([]byte(tc.current), []byte(tc.base), []byte(tc.other))
+			require.NoError(t, err)
+			require.Equal(t, tc.conflicts, conflicts)
+
+			if !tc.conflicts {
+				require.Equal(t, tc.merged, string(merged))
+			}
+		})
+	}
+}
+
+func TestRepo_Tree(t *testing.T) {
+	t.Parallel()
+
+	repo := repository(t, map[string]string{
+		"a.txt":          "a\n",
+		"dir/b.bin":      "b\n",
+		"dir/c.txt":      "c\n",
+		".gitattributes": "*.bin binary\n*.txt text\n",
+	})
+
+	require.NoError(t, exec.Command("git", "-C", repo.Root, "commit", "-q", "--no-verify", "-m", "tree").Run())
+
+	// Files changed after the commit are not considered for the commit.
+	require.NoError(t, os.WriteFile(filepath.Join(repo.Root, "dir", "c.txt"), []byte("changed\n"), 0o600))
+
+	entries, err := repo.Tree("HEAD")
+	require.NoError(t, err)
+
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+
+	require.Equal(t, []string{".gitattributes", "a.txt", "dir/b.bin", "dir/c.txt"}, paths)
+
+	content, err := repo.ReadBlob(entries[3].Hash)
+	require.NoError(t, err)
+	require.Equal(t, "c\n", string(content))
+
+	_, err = repo.Tree("missing")
+	require.ErrorIs(t, err, git.ErrUnknownRevision)
+	require.NotErrorIs(t, err, git.ErrGit)
+}
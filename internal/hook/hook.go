@@ -0,0 +1,99 @@
+// Package hook installs wslint as a git pre-commit hook.
+//
+// The installed hook is a shell script, which first runs the previously installed pre-commit hook (if any),
+// and then runs wslint on the staged content. The previous hook is kept next to it, under the name Chained.
+package hook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// Name is the name of the pre-commit hook.
+	Name = "pre-commit"
+	// Chained is the name under which an existing pre-commit hook is kept, to be run before wslint.
+	Chained = Name + ".wslint-chained"
+	// marker identifies hooks installed by wslint.
+	marker = "# Installed by wslint hook install."
+	// perm are the permissions of the hook (and the hooks directory), which must be executable.
+	perm = 0o755
+)
+
+// ErrChained is returned when an existing hook cannot be chained, because a chained hook already exists.
+var ErrChained = errors.New("cannot chain the existing hook")
+
+// Install installs the pre-commit hook in the hooks directory, running the executable with the arguments.
+// A pre-commit hook previously installed by wslint is replaced, any other hook is chained.
+// It returns whether an existing hook was chained.
+func Install(dir, executable string, args ...string) (chained bool, err error) {
+	if err := os.MkdirAll(dir, perm); err != nil {
+		return false, fmt.Errorf("creating hooks directory: %w", err)
+	}
+
+	hook := filepath.Join(dir, Name)
+
+	existing, err := os.ReadFile(hook)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return false, fmt.Errorf("reading existing hook: %w", err)
+	case !Installed(existing):
+		if _, err := os.Lstat(filepath.Join(dir, Chained)); err == nil {
+			return false, fmt.Errorf("%w: %q already exists", ErrChained, filepath.Join(dir, Chained))
+		}
+
+		if err := os.Rename(hook, filepath.Join(dir, Chained)); err != nil {
+			return false, fmt.Errorf("chaining existing hook: %w", err)
+		}
+
+		chained = true
+	}
+
+	if err := os.WriteFile(hook, Script(executable, args...), perm); err != nil { //nolint:gosec // Hooks are executables.
+		return chained, fmt.Errorf("writing hook: %w", err)
+	}
+
+	// WriteFile does not change the permissions of an existing file.
+	if err := os.Chmod(hook, perm); err != nil { //nolint:gosec // Hooks are executables.
+		return chained, fmt.Errorf("making hook executable: %w", err)
+	}
+
+	return chained, nil
+}
+
+// Installed returns true if the content is a hook installed by wslint.
+func Installed(content []byte) bool {
+	return strings.Contains(string(content), marker)
+}
+
+// Script returns the content of the pre-commit hook, running the executable with the arguments
+// after the chained hook succeeded.
+func Script(executable string, args ...string) []byte {
+	command := []string{quote(executable)}
+	for _, arg := range args {
+		command = append(command, quote(arg))
+	}
+
+	return []byte(fmt.Sprintf(`#!/bin/sh
+%s
+# Lints the staged content. The previous pre-commit hook, if any, is run first as %s.
+
+chained="$(dirname "$0")/%s"
+
+if [ -x "$chained" ]; then
+	"$chained" "$@" || exit $?
+fi
+
+exec %s
+`, marker, Chained, Chained, strings.Join(command, " ")))
+}
+
+// quote quotes the argument for the shell.
+func quote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
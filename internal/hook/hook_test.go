@@ -0,0 +1,105 @@
+package hook_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/hook"
+)
+
+func TestInstall(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existing := []byte("#!/bin/sh\necho existing\n")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hook.Name), existing, 0o755)) //nolint:gosec // Hooks are executables.
+
+	// An existing hook is chained.
+	chained, err := hook.Install(dir, "/path/to/wslint", "hook", "run", "-e", "it's")
+	require.NoError(t, err)
+	require.True(t, chained)
+
+	kept, err := os.ReadFile(filepath.Join(dir, hook.Chained))
+	require.NoError(t, err)
+	require.Equal(t, existing, kept)
+
+	installed, err := os.ReadFile(filepath.Join(dir, hook.Name))
+	require.NoError(t, err)
+	require.True(t, hook.Installed(installed))
+	require.Contains(t, string(installed), `exec '/path/to/wslint' 'hook' 'run' '-e' 'it'\''s'`)
+
+	// Reinstalling replaces the hook installed by wslint, leaving the chained hook alone.
+	chained, err = hook.Install(dir, "/path/to/wslint", "hook", "run", "--fix")
+	require.NoError(t, err)
+	require.False(t, chained)
+
+	installed, err = os.ReadFile(filepath.Join(dir, hook.Name))
+	require.NoError(t, err)
+	require.Contains(t, string(installed), `exec '/path/to/wslint' 'hook' 'run' '--fix'`)
+
+	// Another hook cannot be chained, as the name is taken.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hook.Name), existing, 0o755)) //nolint:gosec // Hooks are executables.
+
+	_, err = hook.Install(dir, "/path/to/wslint")
+	require.ErrorIs(t, err, hook.ErrChained)
+}
+
+func TestScript(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are run by sh")
+	}
+
+	tcs := []struct {
+		name    string // Name of the test case (for logging)
+		chained string // Content of the chained hook, if any
+		output  string // Output expected from running the hook
+		failure bool   // Whether running the hook is expected to fail
+	}{
+		{
+			name:   "no chained hook",
+			output: "wslint ran with: hook run\n",
+		},
+		{
+			name:    "chained hook succeeds",
+			chained: "#!/bin/sh\necho chained ran with: \"$@\"\n",
+			output:  "chained ran with: arg\nwslint ran with: hook run\n",
+		},
+		{
+			name:    "chained hook fails",
+			chained: "#!/bin/sh\necho chained failed\nexit 3\n",
+			output:  "chained failed\n",
+			failure: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			// A fake wslint, echoing its arguments.
+			executable := filepath.Join(dir, "wslint")
+			require.NoError(t, os.WriteFile(executable, []byte("#!/bin/sh\necho wslint ran with: \"$@\"\n"), 0o755)) //nolint:gosec // Test executable.
+
+			if tc.chained != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, hook.Chained), []byte(tc.chained), 0o755)) //nolint:gosec // Test executable.
+			}
+
+			_, err := hook.Install(dir, executable, "hook", "run")
+			require.NoError(t, err)
+
+			output, err := exec.Command(filepath.Join(dir, hook.Name), "arg").Output()
+			require.Equal(t, tc.failure, err != nil)
+			require.Equal(t, tc.output, string(output))
+		})
+	}
+}
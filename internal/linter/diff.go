@@ -0,0 +1,50 @@
+package linter
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// noNewline marks a last line without a trailing newline, as in the output of diff(1).
+const noNewline = "\n\\ No newline at end of file\n"
+
+// UnifiedDiff returns the unified diff between the original and the fixed content of the named file,
+// with 3 lines of context. The file names are prefixed with "a/" and "b/", as with git.
+func UnifiedDiff(name string, original, fixed []byte) (string, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(original),
+		B:        diffLines(fixed),
+		FromFile: path.Join("a", filepath.ToSlash(name)),
+		ToFile:   path.Join("b", filepath.ToSlash(name)),
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating diff: %w", err)
+	}
+
+	return diff, nil
+}
+
+// diffLines splits the content into lines, keeping the newlines.
+// A last line without a newline is marked, so that adding or removing the final newline shows up in the diff.
+func diffLines(content []byte) []string {
+	var lines []string
+
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+
+		if line[len(line)-1] != '\n' {
+			lines = append(lines, string(line)+noNewline)
+		} else {
+			lines = append(lines, string(line))
+		}
+	}
+
+	return lines
+}
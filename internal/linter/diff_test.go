@@ -0,0 +1,48 @@
+package linter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string // Name of the test case (for logging)
+		original string // Original content
+		fixed    string // Fixed content
+		diff     string // Expected diff
+	}{
+		{
+			name:     "no changes",
+			original: "line\n",
+			fixed:    "line\n",
+		},
+		{
+			name:     "trailing whitespace",
+			original: "one \ntwo\n",
+			fixed:    "one\ntwo\n",
+			diff:     "--- a/file.txt\n+++ b/file.txt\n@@ -1,2 +1,2 @@\n-one \n+one\n two\n",
+		},
+		{
+			name:     "missing newline",
+			original: "one",
+			fixed:    "one\n",
+			diff:     "--- a/file.txt\n+++ b/file.txt\n@@ -1 +1 @@\n-one\n\\ No newline at end of file\n+one\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			diff, err := linter.UnifiedDiff("file.txt", []byte(tc.original), []byte(tc.fixed))
+			require.NoError(t, err)
+			require.Equal(t, tc.diff, diff)
+		})
+	}
+}
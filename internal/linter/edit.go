@@ -0,0 +1,175 @@
+package linter
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidEdit is returned when an edit lies outside of the lines it applies to.
+var ErrInvalidEdit = errors.New("invalid edit")
+
+// Edit replaces the bytes between two positions with Text.
+// Lines and columns are 1-based, and columns count bytes. The end position is exclusive.
+// The column after the last byte of a line refers to its newline, so that an edit can span
+// several lines, e.g. deleting the lines 3 and 4 is {Line: 3, Column: 1, EndLine: 5, EndColumn: 1}.
+type Edit struct {
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+	Text      string
+}
+
+// Offsets returns the byte offsets of the start and (exclusive) end of the edit in the content
+// made up of the lines joined with "\n".
+func (e Edit) Offsets(lines [][]byte) (start, end int, err error) {
+	return e.offsets(lines, starts(lines))
+}
+
+// offsets is like Offsets, with the offsets of the starts of the lines precomputed.
+func (e Edit) offsets(lines [][]byte, starts []int) (start, end int, err error) {
+	offset := func(line, column int) (int, error) {
+		if line < 1 || line > len(lines) || column < 1 || column > len(lines[line-1])+1 {
+			return 0, fmt.Errorf("%w: position %d:%d out of range", ErrInvalidEdit, line, column)
+		}
+
+		return starts[line-1] + column - 1, nil
+	}
+
+	if start, err = offset(e.Line, e.Column); err != nil {
+		return 0, 0, err
+	}
+
+	if end, err = offset(e.EndLine, e.EndColumn); err != nil {
+		return 0, 0, err
+	}
+
+	if end < start {
+		return 0, 0, fmt.Errorf("%w: end %d:%d before start %d:%d", ErrInvalidEdit, e.EndLine, e.EndColumn, e.Line, e.Column)
+	}
+
+	return start, end, nil
+}
+
+// starts returns the offsets of the starts of the lines in the content made up of the lines joined with "\n".
+func starts(lines [][]byte) []int {
+	starts := make([]int, len(lines))
+
+	for i := 1; i < len(lines); i++ {
+		starts[i] = starts[i-1] + len(lines[i-1]) + 1
+	}
+
+	return starts
+}
+
+// Edits returns the edits of the issues, in order.
+func Edits(issues []Issue) (edits []Edit) {
+	for _, issue := range issues {
+		edits = append(edits, issue.Edits...)
+	}
+
+	return edits
+}
+
+// span is an edit resolved to byte offsets.
+type span struct {
+	start, end int
+	text       string
+}
+
+// compare orders spans by their start, and then by their end.
+func compare(a, b span) int {
+	return cmp.Or(cmp.Compare(a.start, b.start), cmp.Compare(a.end, b.end))
+}
+
+// overlaps returns true if the spans cannot both be applied.
+// Insertions conflict with each other at the same offset, as their order would be ambiguous.
+func (s span) overlaps(other span) bool {
+	if s.start == s.end && other.start == other.end {
+		return s.start == other.start
+	}
+
+	return s.start < other.end && other.start < s.end ||
+		s.start == s.end && other.start < s.start && s.start < other.end ||
+		other.start == other.end && s.start < other.start && other.start < s.end
+}
+
+// Apply applies the edits to the lines and returns the resulting lines.
+// The edits are considered in order, so that an edit conflicting with (i.e. overlapping) an edit considered
+// before it is not applied, and its index is returned among the conflicts instead. The indices of invalid edits
+// are returned among the conflicts as well. Conflicts are identified by index, as equal edits may be both
+// applied and rejected. The received lines are not modified.
+func Apply(lines [][]byte, edits []Edit) (result [][]byte, conflicts []int) {
+	accepted := make([]span, 0, len(edits))
+	starts := starts(lines)
+
+	for i, edit := range edits {
+		start, end, err := edit.offsets(lines, starts)
+		if err != nil {
+			conflicts = append(conflicts, i)
+
+			continue
+		}
+
+		candidate := span{start: start, end: end, text: edit.Text}
+
+		// The accepted spans are kept sorted and do not overlap, so only the neighbours need to be checked.
+		// Insertions sort before a deletion starting at the same offset.
+		index, _ := slices.BinarySearchFunc(accepted, candidate, compare)
+
+		if slices.ContainsFunc(accepted[max(index-1, 0):min(index+2, len(accepted))], candidate.overlaps) {
+			conflicts = append(conflicts, i)
+
+			continue
+		}
+
+		accepted = slices.Insert(accepted, index, candidate)
+	}
+
+	if len(accepted) == 0 {
+		return lines, conflicts
+	}
+
+	content := bytes.Join(lines, []byte("\n"))
+	fixed := make([]byte, 0, len(content))
+	previous := 0
+
+	for _, span := range accepted {
+		fixed = append(fixed, content[previous:span.start]...)
+		fixed = append(fixed, span.text...)
+		previous = span.end
+	}
+
+	fixed = append(fixed, content[previous:]...)
+
+	return bytes.Split(fixed, []byte("\n")), conflicts
+}
+
+// ApplyLine applies the edits lying within a single line, numbered number, and returns the resulting line.
+// It is meant for streams, which process one line at a time. Edits reaching beyond the line are not applied.
+// Like Apply, it returns the indices of the edits conflicting with an edit before them.
+func ApplyLine(number int, line []byte, edits []Edit) (result []byte, conflicts []int) {
+	shifted := make([]Edit, 0, len(edits))
+	indices := make([]int, 0, len(edits))
+
+	for i, edit := range edits {
+		if edit.Line != number || edit.EndLine != number {
+			continue
+		}
+
+		edit.Line, edit.EndLine = 1, 1
+		shifted = append(shifted, edit)
+		indices = append(indices, i)
+	}
+
+	lines, rejected := Apply([][]byte{line}, shifted)
+
+	for _, index := range rejected {
+		conflicts = append(conflicts, indices[index])
+	}
+
+	return bytes.Join(lines, []byte("\n")), conflicts
+}
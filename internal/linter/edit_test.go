@@ -0,0 +1,119 @@
+package linter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	// edit returns an edit within a single line.
+	edit := func(line, column, end int, text string) linter.Edit {
+		return linter.Edit{Line: line, Column: column, EndLine: line, EndColumn: end, Text: text}
+	}
+
+	tcs := []struct {
+		name      string        // Name of the test case (for logging)
+		content   string        // Content to edit
+		edits     []linter.Edit // Edits to apply, in order of precedence
+		fixed     string        // Content expected after applying the edits
+		conflicts []int         // Indices of the edits expected not to be applied
+	}{
+		{
+			name:    "no edits",
+			content: "line\n",
+			fixed:   "line\n",
+		},
+		{
+			name:    "merges edits out of order",
+			content: "one \ntwo \n",
+			edits:   []linter.Edit{edit(2, 4, 5, ""), edit(1, 4, 5, "")},
+			fixed:   "one\ntwo\n",
+		},
+		{
+			name:    "deletes lines",
+			content: "one\n\n\n\n",
+			edits:   []linter.Edit{{Line: 2, Column: 1, EndLine: 4, EndColumn: 1}},
+			fixed:   "one\n\n",
+		},
+		{
+			name:    "inserts at the end",
+			content: "one",
+			edits:   []linter.Edit{edit(1, 4, 4, "\n")},
+			fixed:   "one\n",
+		},
+		{
+			name:    "adjacent edits",
+			content: "abcdef",
+			edits:   []linter.Edit{edit(1, 3, 5, "X"), edit(1, 1, 3, "Y"), edit(1, 5, 5, "Z")},
+			fixed:   "YXZef",
+		},
+		{
+			name:    "insertion before a deletion at the same position",
+			content: "abc",
+			edits:   []linter.Edit{edit(1, 2, 3, ""), edit(1, 2, 2, "X")},
+			fixed:   "aXc",
+		},
+		{
+			name:      "overlapping edits conflict",
+			content:   "the the value  \n",
+			edits:     []linter.Edit{edit(1, 1, 16, "the value"), edit(1, 14, 16, "")},
+			fixed:     "the value\n",
+			conflicts: []int{1},
+		},
+		{
+			name:      "insertions at the same position conflict",
+			content:   "abc",
+			edits:     []linter.Edit{edit(1, 2, 2, "X"), edit(1, 2, 2, "Y")},
+			fixed:     "aXbc",
+			conflicts: []int{1},
+		},
+		{
+			name:      "insertion within a deletion conflicts",
+			content:   "abc",
+			edits:     []linter.Edit{edit(1, 1, 4, ""), edit(1, 2, 2, "X")},
+			conflicts: []int{1},
+		},
+		{
+			name:      "invalid edits",
+			content:   "abc",
+			edits:     []linter.Edit{edit(1, 3, 6, ""), edit(2, 1, 1, ""), edit(1, 3, 2, "")},
+			fixed:     "abc",
+			conflicts: []int{0, 1, 2},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lines := bytes.Split([]byte(tc.content), []byte("\n"))
+
+			fixed, conflicts := linter.Apply(lines, tc.edits)
+
+			require.Equal(t, tc.fixed, string(bytes.Join(fixed, []byte("\n"))))
+			require.Equal(t, tc.conflicts, conflicts)
+			require.Equal(t, tc.content, string(bytes.Join(lines, []byte("\n"))), "lines modified")
+		})
+	}
+}
+
+func TestApplyLine(t *testing.T) {
+	t.Parallel()
+
+	edits := []linter.Edit{
+		{Line: 3, Column: 4, EndLine: 3, EndColumn: 6},
+		{Line: 2, Column: 1, EndLine: 2, EndColumn: 2},
+		{Line: 3, Column: 1, EndLine: 4, EndColumn: 1},
+		{Line: 3, Column: 5, EndLine: 3, EndColumn: 6, Text: "x"},
+	}
+
+	line, conflicts := linter.ApplyLine(3, []byte("one  "), edits)
+	require.Equal(t, "one", string(line))
+	require.Equal(t, []int{3}, conflicts)
+}
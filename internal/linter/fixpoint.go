@@ -0,0 +1,217 @@
+package linter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// MaxPasses is the maximum number of times the pipeline is applied to reach a fixed point.
+const MaxPasses = 10
+
+var (
+	// ErrNotIdempotent is returned when checkers would still change the fixed content, or report errors in it.
+	ErrNotIdempotent = errors.New("fixes are not idempotent")
+	// ErrNotConverged is returned when the fixes still change the content after MaxPasses passes.
+	ErrNotConverged = errors.New("fixes do not converge")
+)
+
+// Verify relints the fixed lines with each checker and returns ErrNotIdempotent, naming the checkers
+// that would still change them, or that still report errors in them (e.g. issues without edits, or whose edits
+// conflict with the edits of other checkers).
+func (l *Linter) Verify(lines [][]byte) error {
+	var unstable []string
+
+	for _, checker := range l.Checkers {
+		issues := checker.Checker.Check(lines)
+
+		fixed, _ := Apply(lines, Edits(issues))
+		if !slices.EqualFunc(lines, fixed, bytes.Equal) || slices.ContainsFunc(issues, isError) {
+			unstable = append(unstable, checker.Name)
+		}
+	}
+
+	return notIdempotent(unstable)
+}
+
+// isError returns true if the issue has the severity Error.
+func isError(issue Issue) bool {
+	return issue.Severity == Error
+}
+
+// StreamFixed streams the content through the pipeline repeatedly, until it stops changing,
+// for at most MaxPasses passes. Intermediate results are stored in temporary files in dir
+// (or the default directory for temporary files if dir is empty).
+// It returns the temporary file holding the result, positioned at its start, and whether the
+// content was changed at all. The caller is responsible for closing and removing the file.
+// It returns ErrNotConverged, naming the checkers still changing the content, if the content
+// does not stop changing.
+// The issues found are appended to the issues of the linter, unless they were already reported, and the edits
+// dropped and errors found by the last pass are recorded as by Format.
+func (l *Linter) StreamFixed(r io.Reader, dir string) (result *os.File, changed bool, err error) {
+	var (
+		previous *os.File
+		// changing lists the checkers with edits in the previous pass.
+		changing []string
+	)
+
+	defer func() {
+		if previous != nil && previous != result {
+			remove(previous)
+		}
+
+		if err != nil && result != nil {
+			remove(result)
+			result = nil
+		}
+	}()
+
+	for pass := range MaxPasses {
+		if result, err = os.CreateTemp(dir, "wslint-*"); err != nil {
+			return nil, false, fmt.Errorf("creating temporary file: %w", err)
+		}
+
+		lint := New(l.Name, l.Checkers)
+		lint.MaxIssues = l.MaxIssues
+
+		same, err := lint.hashedStream(r, result)
+		if err != nil {
+			return result, false, err
+		}
+
+		l.appendIssues(lint.Issues)
+
+		// Only the conflicts and errors of the last pass are kept, as those of earlier passes are resolved by the
+		// next ones.
+		clear(l.Dropped)
+		maps.Copy(l.Dropped, lint.Dropped)
+		clear(l.Remaining)
+		maps.Copy(l.Remaining, lint.Remaining)
+
+		if _, err := result.Seek(0, io.SeekStart); err != nil {
+			return result, false, fmt.Errorf("rewinding temporary file: %w", err)
+		}
+
+		if same {
+			return result, changed, nil
+		}
+
+		if pass == MaxPasses-1 {
+			return result, true, notConverged(l.Checkers, changing, lint.changing())
+		}
+
+		changing = lint.changing()
+
+		changed = true
+
+		if previous != nil {
+			remove(previous)
+		}
+
+		previous, r = result, result
+	}
+
+	return result, changed, nil
+}
+
+// changing returns the names of the checkers that reported edits, in the order of the pipeline.
+func (l *Linter) changing() (names []string) {
+	for _, checker := range l.Checkers {
+		if slices.ContainsFunc(l.Issues[checker.Name], func(issue Issue) bool { return len(issue.Edits) > 0 }) {
+			names = append(names, checker.Name)
+		}
+	}
+
+	return names
+}
+
+// VerifyStream is like Verify, but streams the fixed content through each checker.
+// The open function is called once for each checker, to read the fixed content from the start.
+func (l *Linter) VerifyStream(open func() (io.ReadCloser, error)) error {
+	var unstable []string
+
+	for _, checker := range l.Checkers {
+		in, err := open()
+		if err != nil {
+			return err
+		}
+
+		lint := New(l.Name, []NamedChecker{checker})
+		lint.MaxIssues = l.MaxIssues
+
+		same, err := lint.hashedStream(in, io.Discard)
+
+		_ = in.Close()
+
+		if err != nil {
+			return err
+		}
+
+		if !same || lint.HasErrors() {
+			unstable = append(unstable, checker.Name)
+		}
+	}
+
+	return notIdempotent(unstable)
+}
+
+// hashedStream streams the content from r to w and reports whether the content was left unchanged.
+func (l *Linter) hashedStream(r io.Reader, w io.Writer) (same bool, err error) {
+	in, out := sha256.New(), sha256.New()
+
+	if err := l.Stream(io.TeeReader(r, in), io.MultiWriter(w, out)); err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(in.Sum(nil), out.Sum(nil)), nil
+}
+
+// appendIssues appends the issues to the issues of the linter, unless they were already reported.
+func (l *Linter) appendIssues(issues map[string][]Issue) {
+	for name, found := range issues {
+		l.addIssues(name, found)
+	}
+}
+
+// notIdempotent returns ErrNotIdempotent naming the unstable checkers, if any.
+func notIdempotent(unstable []string) error {
+	if len(unstable) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: %s would still change the fixed content or report errors in it", ErrNotIdempotent, strings.Join(unstable, ", "),
+	)
+}
+
+// notConverged returns ErrNotConverged naming the checkers still changing the content in the given passes,
+// in the order of the pipeline.
+func notConverged(checkers []NamedChecker, passes ...[]string) error {
+	var names []string
+
+	for _, checker := range checkers {
+		for _, changing := range passes {
+			if slices.Contains(changing, checker.Name) {
+				names = append(names, checker.Name)
+
+				break
+			}
+		}
+	}
+
+	return fmt.Errorf(
+		"%w: %s still change the content after %d passes", ErrNotConverged, strings.Join(names, ", "), MaxPasses,
+	)
+}
+
+// remove closes and removes a temporary file.
+func remove(file *os.File) {
+	_ = file.Close()
+	_ = os.Remove(file.Name())
+}
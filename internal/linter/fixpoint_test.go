@@ -0,0 +1,245 @@
+package linter_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+// changed is the issue reported by lineFunc checkers for each line they change, rewriting the line.
+func changed(row int, line, fixed []byte) linter.Issue {
+	return linter.Issue{
+		Line:      row + 1,
+		Column:    1,
+		EndColumn: 1,
+		Message:   "changed",
+		Edits:     []linter.Edit{{Line: row + 1, Column: 1, EndLine: row + 1, EndColumn: len(line) + 1, Text: string(fixed)}},
+	}
+}
+
+// lineFunc is a checker applying a function to each line, both in memory and as a stream.
+type lineFunc func([]byte) []byte
+
+func (f lineFunc) Check(lines [][]byte) (issues []linter.Issue) {
+	for i, line := range lines {
+		if fixed := f(line); !bytes.Equal(fixed, line) {
+			issues = append(issues, changed(i, line, fixed))
+		}
+	}
+
+	return issues
+}
+
+func (f lineFunc) Stream() linter.Stream {
+	return &lineFuncStream{f: f}
+}
+
+type lineFuncStream struct {
+	f   lineFunc
+	row int
+}
+
+func (s *lineFuncStream) Next(line []byte) (issues []linter.Issue) {
+	if fixed := s.f(line); !bytes.Equal(fixed, line) {
+		issues = append(issues, changed(s.row, line, fixed))
+	}
+
+	s.row++
+
+	return issues
+}
+
+func (s *lineFuncStream) Held() int {
+	return 0
+}
+
+func (s *lineFuncStream) Close() []linter.Issue {
+	return nil
+}
+
+// stripDot removes a trailing dot, which can leave trailing whitespace behind.
+func stripDot(line []byte) []byte {
+	return bytes.TrimSuffix(line, []byte("."))
+}
+
+// padX appends a space to lines ending with "x", which is never stable in combination with Whitespace.
+func padX(line []byte) []byte {
+	if bytes.HasSuffix(line, []byte("x")) {
+		return append(bytes.Clone(line), ' ')
+	}
+
+	return line
+}
+
+func TestLinter_FixedPoint(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string   // Name of the test case (for logging)
+		checker  lineFunc // Checker to run after the whitespace checker
+		content  string   // Content to fix
+		fixed    string   // Content expected after fixing
+		unstable bool     // Whether the fixes are expected to be not idempotent
+	}{
+		{
+			name:    "converges",
+			checker: stripDot,
+			content: "a .\nb\n",
+			fixed:   "a\nb\n",
+		},
+		{
+			name:     "oscillates",
+			checker:  padX,
+			content:  "ax\n",
+			unstable: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pipeline := []linter.NamedChecker{
+				{Name: "whitespace", Checker: checkers.Whitespace{}},
+				{Name: "custom", Checker: tc.checker},
+			}
+
+			// In memory
+			formatter := linter.New(tc.name, pipeline)
+			lines, formatErr := formatter.Format(bytes.Split([]byte(tc.content), []byte("\n")))
+
+			// Streamed
+			streamer := linter.New(tc.name, pipeline)
+
+			result, changed, streamErr := streamer.StreamFixed(strings.NewReader(tc.content), t.TempDir())
+
+			if tc.unstable {
+				require.ErrorIs(t, formatErr, linter.ErrNotConverged)
+				require.ErrorContains(t, formatErr, "custom")
+				require.ErrorIs(t, streamErr, linter.ErrNotConverged)
+				require.ErrorContains(t, streamErr, "custom")
+				require.Nil(t, result)
+
+				require.ErrorIs(t, formatter.Verify(lines), linter.ErrNotIdempotent)
+				require.ErrorContains(t, formatter.Verify(lines), "custom")
+
+				return
+			}
+
+			require.NoError(t, formatErr)
+			require.NoError(t, streamErr)
+			require.True(t, changed)
+
+			t.Cleanup(func() { _ = result.Close() })
+
+			streamed, err := io.ReadAll(result)
+			require.NoError(t, err)
+
+			open := func() (io.ReadCloser, error) { return os.Open(result.Name()) }
+
+			require.Equal(t, tc.fixed, string(bytes.Join(lines, []byte("\n"))))
+			require.Equal(t, tc.fixed, string(streamed))
+			require.NoError(t, formatter.Verify(lines))
+			require.NoError(t, streamer.VerifyStream(open))
+
+			// The trailing whitespace left behind by the custom checker is reported by a later pass.
+			require.Len(t, formatter.Issues["whitespace"], 1)
+			require.Len(t, streamer.Issues["whitespace"], 1)
+		})
+	}
+}
+
+func TestLinter_FixedPointUnfixable(t *testing.T) {
+	t.Parallel()
+
+	pipeline := []linter.NamedChecker{
+		{Name: "custom", Checker: lineFunc(func(line []byte) []byte { return bytes.TrimPrefix(line, []byte(".")) })},
+		{Name: "rule", Checker: checkers.Rule{Pattern: regexp.MustCompile("FIXME")}},
+	}
+
+	formatter := linter.New("unfixable", pipeline)
+	lines, err := formatter.Format(bytes.Split([]byte(".FIXME\n"), []byte("\n")))
+	require.NoError(t, err)
+
+	require.Equal(t, "FIXME\n", string(bytes.Join(lines, []byte("\n"))))
+
+	// The issue without edits is not reported again by the later pass, where the fix moved it.
+	require.Len(t, formatter.Issues["rule"], 1)
+	require.Equal(t, 2, formatter.Issues["rule"][0].Column)
+
+	// The issue remains after fixing, which fails the verification.
+	require.ErrorIs(t, formatter.Verify(lines), linter.ErrNotIdempotent)
+	require.ErrorContains(t, formatter.Verify(lines), "rule")
+
+	open := func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("FIXME\n")), nil }
+	require.ErrorContains(t, formatter.VerifyStream(open), "rule")
+}
+
+// rewrite rewrites each line with itself, which conflicts with any other edit of the line without changing it.
+type rewrite struct{}
+
+func (rewrite) Check(lines [][]byte) (issues []linter.Issue) {
+	for i, line := range lines {
+		issues = append(issues, changed(i, line, line))
+	}
+
+	return issues
+}
+
+func (rewrite) Stream() linter.Stream {
+	return &rewriteStream{}
+}
+
+type rewriteStream struct {
+	row int
+}
+
+func (s *rewriteStream) Next(line []byte) []linter.Issue {
+	defer func() { s.row++ }()
+
+	return []linter.Issue{changed(s.row, line, line)}
+}
+
+func (s *rewriteStream) Held() int {
+	return 0
+}
+
+func (s *rewriteStream) Close() []linter.Issue {
+	return nil
+}
+
+func TestLinter_Dropped(t *testing.T) {
+	t.Parallel()
+
+	pipeline := []linter.NamedChecker{
+		{Name: "rewrite", Checker: rewrite{}},
+		{Name: "stutter", Checker: checkers.Stutter{}},
+	}
+
+	// In memory
+	formatter := linter.New("dropped", pipeline)
+	lines, err := formatter.Format(bytes.Split([]byte("the the end\n"), []byte("\n")))
+	require.NoError(t, err)
+
+	require.Equal(t, "the the end\n", string(bytes.Join(lines, []byte("\n"))))
+	require.Equal(t, map[string]int{"stutter": 1}, formatter.Dropped)
+
+	// Streamed
+	streamer := linter.New("dropped", pipeline)
+
+	result, changed, err := streamer.StreamFixed(strings.NewReader("the the end\n"), t.TempDir())
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	t.Cleanup(func() { _ = result.Close() })
+
+	require.Equal(t, map[string]int{"stutter": 1}, streamer.Dropped)
+}
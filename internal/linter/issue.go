@@ -0,0 +1,119 @@
+package linter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownSeverity is returned when parsing an unknown severity.
+var ErrUnknownSeverity = errors.New("unknown severity")
+
+// Severity is the severity of an issue.
+type Severity int
+
+const (
+	// Error is the severity of issues that fail the run, and the default.
+	Error Severity = iota
+	// Warning is the severity of issues that are reported, but do not fail the run.
+	Warning
+)
+
+// String returns the name of the severity, "error" or "warning".
+func (s Severity) String() string {
+	if s == Warning {
+		return "warning"
+	}
+
+	return "error"
+}
+
+// ParseSeverity parses the name of a severity, "error" or "warning" (ignoring case).
+// An empty name selects Error.
+func ParseSeverity(name string) (Severity, error) {
+	switch strings.ToLower(name) {
+	case "", "error":
+		return Error, nil
+	case "warning":
+		return Warning, nil
+	default:
+		return Error, fmt.Errorf("%w: %q, must be one of 'error' or 'warning'", ErrUnknownSeverity, name)
+	}
+}
+
+// Issue is a single issue reported by a checker.
+// Lines and columns are 1-based, and columns count bytes.
+type Issue struct {
+	// Checker is the name of the checker reporting the issue. It is set by the linter.
+	Checker string
+	// Line is the line of the issue.
+	Line int
+	// Column is the column where the issue starts.
+	Column int
+	// EndColumn is the column where the issue ends (exclusive).
+	EndColumn int
+	// Message describes the issue.
+	Message string
+	// Fix describes how the issue is fixed, or is empty if it cannot be fixed automatically.
+	Fix string
+	// Edits are the edits fixing the issue, if any.
+	Edits []Edit
+	// Severity is the severity of the issue, Error by default.
+	Severity Severity
+}
+
+// String returns the issue formatted as "line:column: message".
+func (i Issue) String() string {
+	return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i.Message)
+}
+
+// Collapse groups the issues with the same message on contiguous lines, and formats each group as
+// "message: line 3, column 5" for single issues, or "message: lines 3-10" for ranges.
+// The issues are expected to be ordered by line.
+func Collapse(issues []Issue) []string {
+	var collapsed []string
+
+	for _, issues := range group(issues) {
+		collapsed = append(collapsed, describe(issues))
+	}
+
+	return collapsed
+}
+
+// group splits the issues into groups with the same message on contiguous lines.
+func group(issues []Issue) (groups [][]Issue) {
+	for start := 0; start < len(issues); {
+		end := start + 1
+		for end < len(issues) &&
+			issues[end].Message == issues[start].Message &&
+			issues[end].Line == issues[end-1].Line+1 {
+			end++
+		}
+
+		groups = append(groups, issues[start:end])
+
+		start = end
+	}
+
+	return groups
+}
+
+// describe formats a group of issues.
+func describe(issues []Issue) string {
+	first, last := issues[0], issues[len(issues)-1]
+
+	if first.Line == last.Line {
+		return fmt.Sprintf("%s: line %d, %s", first.Message, first.Line, columns(first))
+	}
+
+	return fmt.Sprintf("%s: lines %d-%d", first.Message, first.Line, last.Line)
+}
+
+// columns formats the column span of an issue.
+func columns(issue Issue) string {
+	if issue.EndColumn <= issue.Column+1 {
+		return fmt.Sprintf("column %d", issue.Column)
+	}
+
+	return fmt.Sprintf("columns %d-%d", issue.Column, issue.EndColumn-1)
+}
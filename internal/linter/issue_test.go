@@ -0,0 +1,144 @@
+package linter_test
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+func TestCollapse(t *testing.T) {
+	t.Parallel()
+
+	trailing := func(line, column, end int) linter.Issue {
+		return linter.Issue{Line: line, Column: column, EndColumn: end, Message: "has trailing whitespace"}
+	}
+
+	tcs := []struct {
+		name      string         // Name of the test case (for logging)
+		issues    []linter.Issue // Issues to collapse
+		collapsed []string       // Expected output
+	}{
+		{
+			name: "no issues",
+		},
+		{
+			name:      "single issue",
+			issues:    []linter.Issue{trailing(3, 5, 7)},
+			collapsed: []string{"has trailing whitespace: line 3, columns 5-6"},
+		},
+		{
+			name:      "single column",
+			issues:    []linter.Issue{trailing(1, 4, 5)},
+			collapsed: []string{"has trailing whitespace: line 1, column 4"},
+		},
+		{
+			name:   "contiguous lines",
+			issues: []linter.Issue{trailing(1, 2, 3), trailing(3, 1, 2), trailing(4, 1, 2), trailing(5, 1, 2)},
+			collapsed: []string{
+				"has trailing whitespace: line 1, column 2",
+				"has trailing whitespace: lines 3-5",
+			},
+		},
+		{
+			name: "different messages",
+			issues: []linter.Issue{
+				trailing(1, 2, 3),
+				{Line: 2, Column: 1, EndColumn: 8, Message: "stutters (the the)"},
+				trailing(3, 2, 3),
+			},
+			collapsed: []string{
+				"has trailing whitespace: line 1, column 2",
+				"stutters (the the): line 2, columns 1-7",
+				"has trailing whitespace: line 3, column 2",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.collapsed, linter.Collapse(tc.issues))
+		})
+	}
+}
+
+func TestLinter_MaxIssues(t *testing.T) {
+	t.Parallel()
+
+	content := strings.Repeat("line \n", 5)
+	pipeline := []linter.NamedChecker{{Name: "whitespace", Checker: checkers.Whitespace{}}}
+
+	formatter := linter.New("format", pipeline)
+	formatter.MaxIssues = 2
+	_, err := formatter.Format(bytes.Split([]byte(content), []byte("\n")))
+	require.NoError(t, err)
+
+	streamer := linter.New("stream", pipeline)
+	streamer.MaxIssues = 2
+	require.NoError(t, streamer.Stream(strings.NewReader(content), nil))
+
+	for _, lint := range []*linter.Linter{formatter, streamer} {
+		require.Len(t, lint.Issues["whitespace"], 2, lint.Name)
+		require.Equal(t, 3, lint.Omitted["whitespace"], lint.Name)
+	}
+}
+
+func TestLinter_Fixable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		pipeline []linter.NamedChecker
+		content  string
+		fixable  bool
+	}{
+		{
+			name:     "Fixed issues",
+			pipeline: []linter.NamedChecker{{Name: "whitespace", Checker: checkers.Whitespace{}}},
+			content:  "a \nb\t\n",
+			fixable:  true,
+		},
+		{
+			name:     "Issue without edits",
+			pipeline: []linter.NamedChecker{{Name: "rule", Checker: checkers.Rule{Pattern: regexp.MustCompile("FIXME")}}},
+			content:  "FIXME\n",
+			fixable:  false,
+		},
+		{
+			name: "Issue whose edits conflict",
+			pipeline: []linter.NamedChecker{
+				{Name: "rewrite", Checker: rewrite{}},
+				{Name: "stutter", Checker: checkers.Stutter{}},
+			},
+			content: "the the end\n",
+			fixable: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			formatter := linter.New("fixable", test.pipeline)
+			_, err := formatter.Format(bytes.Split([]byte(test.content), []byte("\n")))
+			require.NoError(t, err)
+			require.Equal(t, test.fixable, formatter.Fixable(), "format")
+
+			streamer := linter.New("fixable", test.pipeline)
+
+			result, _, err := streamer.StreamFixed(strings.NewReader(test.content), t.TempDir())
+			require.NoError(t, err)
+
+			t.Cleanup(func() { _ = result.Close() })
+
+			require.Equal(t, test.fixable, streamer.Fixable(), "stream")
+		})
+	}
+}
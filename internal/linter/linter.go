@@ -1,46 +1,109 @@
-// Package linter provides a high-level interface to organize line-base formatting of a sequence of strings.
+// Package linter provides a high-level interface to organize line-base formatting of a sequence of lines.
+//
+// Lines are byte slices, split on "\n" (without the delimiter).
+// If all checkers in use implement the Streamer interface, the lines can be streamed through
+// the checkers, without loading the whole content into memory.
 package linter
 
 import (
+	"bytes"
+	"fmt"
 	"log"
+	"slices"
+	"strings"
 
 	"github.com/fatih/color"
-
-	"github.com/idelchi/wslint/internal/checkers"
 )
 
 // Checker represents a line analyser.
 type Checker interface {
-	Format(lines []string) ([]string, []error)
+	// Check returns the issues found in the lines, along with the edits fixing them.
+	// The lines must not be modified.
+	Check(lines [][]byte) []Issue
+}
+
+// Streamer is implemented by checkers that can process the lines one at a time.
+type Streamer interface {
+	Checker
+	// Stream returns a new stream, to process the lines of a single sequence.
+	Stream() Stream
+}
+
+// Stream checks a sequence of lines, one at a time.
+// The issues found must be the same as those found by Check on the whole sequence.
+type Stream interface {
+	// Next checks the next line and returns the issues found, with edits confined to that line
+	// and the lines held back after the previous call. Edits spanning lines must remove whole lines
+	// (from the start of a line to the start of the next one), unless they are returned by Close.
+	// The line is only valid until Next returns.
+	Next(line []byte) []Issue
+	// Held returns the number of most recent lines that the issues returned by later calls may still edit
+	// (e.g. trailing blank lines). These lines are held back by the linter.
+	Held() int
+	// Close returns the issues that are only known once all lines are checked.
+	Close() []Issue
+}
+
+// NamedChecker is a checker along with the name it reports its issues under.
+type NamedChecker struct {
+	Name    string
+	Checker Checker
 }
 
 // Linter represents a text linter.
 type Linter struct {
 	Name string
-	// Checkers is a map with name and checker to use.
-	Checkers map[string]Checker
-	// Error contains the error, if any.
-	Errors map[string][]error
-	// The lines formatted.
-	Lines []string
+	// Checkers is the pipeline of checkers to use, in the order they are applied.
+	Checkers []NamedChecker
+	// Issues contains the issues found, by checker name.
+	// Use Checkers to iterate over them in a deterministic order.
+	Issues map[string][]Issue
+	// MaxIssues is the maximum number of issues kept per checker, or 0 to keep all.
+	// Limiting the issues kept bounds the memory used for files with an issue on (almost) every line.
+	MaxIssues int
+	// Omitted contains the number of issues found but not kept, by checker name.
+	Omitted map[string]int
+	// Dropped contains the number of edits not applied by the last pass of the pipeline, as they conflict with
+	// the edits of earlier checkers, by checker name. Their issues remain in the fixed content.
+	Dropped map[string]int
+	// Remaining contains the number of errors found by the last pass of the pipeline, by checker name.
+	// After fixing, these are the errors remaining in the fixed content.
+	Remaining map[string]int
+	// Context is the number of lines shown around the offending lines in the summary.
+	Context int
+	// Source contains the source lines shown in the summary, by (1-based) line number.
+	Source map[int][]byte
+	// Diff contains the unified diff of the fixes, if requested.
+	Diff string
+	// Error contains the error encountered while reading or writing the file, if any.
+	Error error
+	// Fixed is true if the issues found were written back to the file.
+	Fixed bool
 }
 
-// InsertChecker adds a checker to the list of checkers in use.
+// InsertChecker adds a checker to the end of the pipeline,
+// or replaces the checker with the same name while keeping its position.
 func (l *Linter) InsertChecker(name string, c Checker) {
-	l.Checkers[name] = c
-}
+	for i := range l.Checkers {
+		if l.Checkers[i].Name == name {
+			l.Checkers[i].Checker = c
 
-// New creates a new linter, with the default checkers.
-func New(name string) *Linter {
-	defaultCheckers := map[string]Checker{
-		"whitespace": checkers.Whitespace{},
-		"blanks":     checkers.Blanks{},
+			return
+		}
 	}
 
+	l.Checkers = append(l.Checkers, NamedChecker{Name: name, Checker: c})
+}
+
+// New creates a new linter with the given pipeline of checkers.
+func New(name string, checkers []NamedChecker) *Linter {
 	return &Linter{
-		Name:     name,
-		Checkers: defaultCheckers,
-		Errors:   make(map[string][]error),
+		Name:      name,
+		Checkers:  checkers,
+		Issues:    make(map[string][]Issue),
+		Omitted:   make(map[string]int),
+		Dropped:   make(map[string]int),
+		Remaining: make(map[string]int),
 	}
 }
 
@@ -49,49 +112,258 @@ func (l *Linter) HasCheckers() bool {
 	return len(l.Checkers) > 0
 }
 
+// HasError returns true if the file could not be processed.
+func (l *Linter) HasError() bool {
+	return l.Error != nil
+}
+
 // HasIssues returns true if the linter has issues.
 func (l *Linter) HasIssues() bool {
-	return len(l.Errors) > 0
+	return len(l.Issues) > 0
 }
 
-// Format returns the formatted string.
-func (l *Linter) Format(lines []string) []string {
+// HasErrors returns true if the linter has issues with the severity Error.
+func (l *Linter) HasErrors() bool {
+	for _, issues := range l.Issues {
+		for _, issue := range issues {
+			if issue.Severity == Error {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Fixable returns true if the last pass of the pipeline found no errors, i.e. no errors remain after fixing.
+// Errors without edits (e.g. a misspelling with several corrections), or whose edits conflict with the edits
+// of earlier checkers, remain after fixing.
+func (l *Linter) Fixable() bool {
+	return len(l.Remaining) == 0
+}
+
+// Streamable returns true if all checkers in use implement the Streamer interface.
+func (l *Linter) Streamable() bool {
+	for _, checker := range l.Checkers {
+		if _, ok := checker.Checker.(Streamer); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Format checks the lines with the pipeline of checkers and returns the lines with the edits of the issues
+// applied. All checkers check the same lines, and their edits are merged, with the edits of checkers
+// earlier in the pipeline taking precedence over conflicting edits of later checkers.
+// The pipeline is repeated until the lines stop changing, for at most MaxPasses passes, so that
+// conflicting edits are applied in a later pass, and the fix of one checker creating a violation for
+// another is fixed as well. Issues found in later passes are appended to the issues of the first pass,
+// unless they were already reported or carry no edits (as they are issues of the first pass, moved by the fixes).
+// It returns ErrNotConverged, naming the checkers still changing the lines, along with the lines of the last pass
+// if the lines do not stop changing.
+func (l *Linter) Format(lines [][]byte) ([][]byte, error) {
 	if !l.HasCheckers() {
 		panic("no checkers configured")
 	}
 
-	var errors []error
-	for name, checker := range l.Checkers {
-		if lines, errors = checker.Format(lines); len(errors) > 0 {
-			l.Errors[name] = errors
+	clear(l.Issues)
+	clear(l.Omitted)
+
+	// Keep the original lines, to capture the source lines of the issues.
+	source := lines
+
+	// changing lists the checkers with edits in the last two passes, in case the lines do not stop changing
+	// (e.g. two checkers undoing each other's fixes).
+	var changing, previous []string
+
+	for pass := range MaxPasses {
+		var (
+			edits  []Edit
+			owners []string
+		)
+
+		previous, changing = changing, nil
+
+		// Only the errors of the last pass remain, as those of earlier passes are fixed by the next ones.
+		clear(l.Remaining)
+
+		for _, checker := range l.Checkers {
+			issues := checker.Checker.Check(lines)
+
+			l.remain(checker.Name, issues)
+
+			if pass == 0 {
+				l.collect(checker.Name, issues)
+			} else {
+				l.addIssues(checker.Name, slices.DeleteFunc(slices.Clone(issues), func(issue Issue) bool {
+					return len(issue.Edits) == 0
+				}))
+			}
+
+			found := Edits(issues)
+			edits = append(edits, found...)
+			owners = append(owners, slices.Repeat([]string{checker.Name}, len(found))...)
+
+			if len(found) > 0 {
+				changing = append(changing, checker.Name)
+			}
+		}
+
+		fixed, conflicts := Apply(lines, edits)
+
+		// Only the conflicts of the last pass are kept, as those of earlier passes are resolved by the next ones.
+		clear(l.Dropped)
+
+		for _, index := range conflicts {
+			l.Dropped[owners[index]]++
+		}
+
+		if slices.EqualFunc(lines, fixed, bytes.Equal) {
+			break
+		}
+
+		lines = fixed
+
+		if pass == MaxPasses-1 {
+			l.capture(source)
+
+			return lines, notConverged(l.Checkers, previous, changing)
+		}
+	}
+
+	l.capture(source)
+
+	return lines, nil
+}
+
+// remain counts the issues with the severity Error as remaining for the named checker.
+func (l *Linter) remain(name string, issues []Issue) {
+	for _, issue := range issues {
+		if issue.Severity == Error {
+			l.Remaining[name]++
 		}
 	}
+}
+
+// collect appends the issues to the issues of the named checker, attributing them to it,
+// and counts the issues beyond MaxIssues as omitted.
+func (l *Linter) collect(name string, issues []Issue) {
+	for _, issue := range issues {
+		if l.MaxIssues > 0 && len(l.Issues[name]) >= l.MaxIssues {
+			l.Omitted[name]++
 
-	return lines
+			continue
+		}
+
+		issue.Checker = name
+		l.Issues[name] = append(l.Issues[name], issue)
+	}
 }
 
-// Summary prints a summary of the file.
+// addIssues is like collect, but skips the issues that were already reported (e.g. in an earlier pass),
+// and does not count the issues beyond MaxIssues.
+func (l *Linter) addIssues(name string, issues []Issue) {
+	type key struct {
+		line, column, end int
+		message           string
+	}
+
+	var reported map[key]bool
+
+	if existing := l.Issues[name]; len(existing) > 0 {
+		reported = make(map[key]bool, len(existing))
+		for _, issue := range existing {
+			reported[key{issue.Line, issue.Column, issue.EndColumn, issue.Message}] = true
+		}
+	}
+
+	for _, issue := range issues {
+		if l.MaxIssues > 0 && len(l.Issues[name]) >= l.MaxIssues {
+			return
+		}
+
+		if reported[key{issue.Line, issue.Column, issue.EndColumn, issue.Message}] {
+			continue
+		}
+
+		issue.Checker = name
+		l.Issues[name] = append(l.Issues[name], issue)
+	}
+}
+
+// printDiff prints the diff of the fixes, if any, with the added and removed lines coloured.
+func (l *Linter) printDiff() {
+	added := color.New(color.FgGreen).SprintFunc()
+	removed := color.New(color.FgRed).SprintFunc()
+
+	for _, line := range strings.SplitAfter(l.Diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			log.Print(line)
+		case strings.HasPrefix(line, "+"):
+			log.Print(added(line))
+		case strings.HasPrefix(line, "-"):
+			log.Print(removed(line))
+		case line != "":
+			log.Print(line)
+		}
+	}
+}
+
+// Summary prints a summary of the file, and returns true if it has no errors.
+// Warnings are printed, but do not fail the file.
 func (l *Linter) Summary() (ok bool) {
 	// Use coloured output for emphasis
 	filename := color.New(color.FgGreen, color.Bold).SprintFunc()
 	errorColor := color.New(color.FgRed).SprintFunc()
+	warningColor := color.New(color.FgYellow).SprintFunc()
+
+	if l.HasError() {
+		log.Println(filename(l.Name))
+		log.Printf("  - Error processing file: %s", errorColor(l.Error))
 
-	ok = !l.HasIssues()
+		return false
+	}
+
+	ok = !l.HasErrors()
 
-	if !ok {
+	if l.HasIssues() {
 		log.Println(filename(l.Name))
-		errors := l.Errors
 
-		// Get all the keys in errors
-		// checkers := maps.Keys(errors)
+		for _, checker := range l.Checkers {
+			issues, found := l.Issues[checker.Name]
+			if !found {
+				continue
+			}
+
+			// The issues of a checker share their severity.
+			kind, colorize := "Errors", errorColor
+			if issues[0].Severity == Warning {
+				kind, colorize = "Warnings", warningColor
+			}
+
+			log.Printf("  - %s detected:  %s", kind, colorize(checker.Name))
 
-		for name, errors := range errors {
-			log.Println("  - Errors detected: ", errorColor(name))
+			for _, issues := range group(issues) {
+				log.Printf("    - %s", colorize(describe(issues)))
 
-			for _, err := range errors {
-				log.Printf("    - %s", errorColor(err))
+				for _, line := range l.snippet(issues) {
+					log.Println(line)
+				}
+			}
+
+			if omitted := l.Omitted[checker.Name]; omitted > 0 {
+				log.Printf("    - %s", colorize(fmt.Sprintf("... and %d more issues", omitted)))
+			}
+
+			if dropped := l.Dropped[checker.Name]; dropped > 0 {
+				log.Printf("    - %s", colorize(fmt.Sprintf(
+					"%d fixes not applied, as they conflict with the fixes of earlier checkers", dropped)))
 			}
 		}
+
+		l.printDiff()
 	}
 
 	return ok
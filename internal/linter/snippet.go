@@ -0,0 +1,198 @@
+package linter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// maxSnippetLines is the maximum number of offending lines shown for a group of issues.
+const maxSnippetLines = 5
+
+// glyphs renders trailing whitespace visibly. Other whitespace is rendered as a space glyph.
+//
+//nolint:gochecknoglobals // Lookup table.
+var glyphs = map[rune]string{
+	' ':      "·",
+	'\t':     "→",
+	'\r':     "¤",
+	'\u00a0': "⍽",
+}
+
+// Capture reads the source lines from r and keeps those shown in the report of the issues found.
+func (l *Linter) Capture(r io.Reader) error {
+	shown := l.shown()
+	if len(shown) == 0 {
+		return nil
+	}
+
+	l.Source = make(map[int][]byte, len(shown))
+
+	in := newLineReader(r)
+
+	for number := 1; ; number++ {
+		line, err := in.next()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("reading lines: %w", err)
+		}
+
+		if shown[number] {
+			l.Source[number] = bytes.Clone(line)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
+
+// capture keeps the source lines shown in the report of the issues found.
+func (l *Linter) capture(lines [][]byte) {
+	l.Source = make(map[int][]byte)
+
+	for number := range l.shown() {
+		if number <= len(lines) {
+			l.Source[number] = lines[number-1]
+		}
+	}
+}
+
+// shown returns the (1-based) numbers of the lines shown in the report of the issues found.
+func (l *Linter) shown() map[int]bool {
+	shown := make(map[int]bool)
+
+	for _, issues := range l.Issues {
+		for _, issues := range group(issues) {
+			first, last := l.span(issues)
+			for number := first; number <= last; number++ {
+				shown[number] = true
+			}
+		}
+	}
+
+	return shown
+}
+
+// span returns the first and last line shown for a group of issues, including the context.
+func (l *Linter) span(issues []Issue) (first, last int) {
+	first = max(issues[0].Line-l.Context, 1)
+	last = min(issues[len(issues)-1].Line, issues[0].Line+maxSnippetLines-1) + l.Context
+
+	return first, last
+}
+
+// snippet renders the source lines of a group of issues, with a gutter holding the line numbers,
+// and a caret underline below each offending line. Lines that were not captured are left out.
+func (l *Linter) snippet(issues []Issue) []string {
+	gutterColor := color.New(color.FgBlue, color.Bold).SprintFunc()
+	caretColor := color.New(color.FgRed, color.Bold).SprintFunc()
+
+	first, last := l.span(issues)
+
+	byLine := make(map[int]Issue, len(issues))
+	for _, issue := range issues {
+		byLine[issue.Line] = issue
+	}
+
+	width := len(fmt.Sprint(last))
+	gutter := func(label string) string {
+		return "      " + gutterColor(fmt.Sprintf("%*s |", width, label))
+	}
+
+	var snippet []string
+
+	for number := first; number <= last; number++ {
+		line, ok := l.Source[number]
+		if !ok {
+			continue
+		}
+
+		cells := render(line)
+
+		snippet = append(snippet, strings.TrimRight(gutter(fmt.Sprint(number))+" "+join(cells), " "))
+
+		if issue, ok := byLine[number]; ok {
+			snippet = append(snippet, gutter("")+" "+caretColor(carets(cells, issue)))
+		}
+	}
+
+	if shown := min(len(issues), maxSnippetLines); len(snippet) > 0 && shown < len(issues) {
+		snippet = append(snippet, gutter("")+fmt.Sprintf(" ... and %d more lines", len(issues)-shown))
+	}
+
+	return snippet
+}
+
+// cell is a single rendered character of a line, along with its byte offset in the line.
+type cell struct {
+	text   string
+	offset int
+}
+
+// render splits a line into cells, one for each rune, rendering the trailing whitespace with visible glyphs.
+func render(line []byte) []cell {
+	trailing := len(bytes.TrimRightFunc(line, unicode.IsSpace))
+
+	var cells []cell
+
+	for offset := 0; offset < len(line); {
+		r, size := utf8.DecodeRune(line[offset:])
+		text := string(line[offset : offset+size])
+
+		if offset >= trailing {
+			if glyph, ok := glyphs[r]; ok {
+				text = glyph
+			} else {
+				text = glyphs[' ']
+			}
+		}
+
+		cells = append(cells, cell{text: text, offset: offset})
+		offset += size
+	}
+
+	return cells
+}
+
+// join concatenates the rendered cells.
+func join(cells []cell) string {
+	var builder strings.Builder
+
+	for _, cell := range cells {
+		builder.WriteString(cell.text)
+	}
+
+	return builder.String()
+}
+
+// carets returns the underline of the span of an issue, aligned with the rendered cells.
+// Tabs are kept in the padding, so that the carets line up with tab-indented lines.
+func carets(cells []cell, issue Issue) string {
+	start, end := issue.Column-1, issue.EndColumn-1
+
+	var padding, underline strings.Builder
+
+	for _, cell := range cells {
+		switch {
+		case cell.offset < start && cell.text == "\t":
+			padding.WriteByte('\t')
+		case cell.offset < start:
+			padding.WriteByte(' ')
+		case cell.offset < end:
+			underline.WriteByte('^')
+		}
+	}
+
+	// Zero-width issues (e.g. a missing line) are marked at their position.
+	if underline.Len() == 0 {
+		underline.WriteByte('^')
+	}
+
+	return padding.String() + underline.String()
+}
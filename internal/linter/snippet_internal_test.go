@@ -0,0 +1,73 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string // Name of the test case (for logging)
+		line     string // Line to render
+		issue    Issue  // Issue to underline
+		rendered string // Expected rendered line
+		carets   string // Expected underline
+	}{
+		{
+			name:     "trailing spaces",
+			line:     "text  ",
+			issue:    Issue{Column: 5, EndColumn: 7},
+			rendered: "text··",
+			carets:   "    ^^",
+		},
+		{
+			name:     "visible glyphs",
+			line:     "text\t \r",
+			issue:    Issue{Column: 5, EndColumn: 9},
+			rendered: "text→⍽¤",
+			carets:   "    ^^^",
+		},
+		{
+			name:     "leading tabs are kept",
+			line:     "\tindented ",
+			issue:    Issue{Column: 10, EndColumn: 11},
+			rendered: "\tindented·",
+			carets:   "\t        ^",
+		},
+		{
+			name:     "inner whitespace is not rendered",
+			line:     "the the end",
+			issue:    Issue{Column: 1, EndColumn: 8},
+			rendered: "the the end",
+			carets:   "^^^^^^^",
+		},
+		{
+			name:     "multi-byte characters",
+			line:     "héllo ",
+			issue:    Issue{Column: 7, EndColumn: 8},
+			rendered: "héllo·",
+			carets:   "     ^",
+		},
+		{
+			name:     "zero-width issue",
+			line:     "last",
+			issue:    Issue{Column: 5, EndColumn: 5},
+			rendered: "last",
+			carets:   "    ^",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cells := render([]byte(tc.line))
+
+			require.Equal(t, tc.rendered, join(cells))
+			require.Equal(t, tc.carets, carets(cells, tc.issue))
+		})
+	}
+}
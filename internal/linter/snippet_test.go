@@ -0,0 +1,43 @@
+package linter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+// Capturing the source from a stream keeps the same lines as formatting in memory.
+func TestCapture(t *testing.T) {
+	t.Parallel()
+
+	content := "one\ntwo \nthree\nfour\nfive\nsix \nseven\n"
+
+	for _, context := range []int{0, 1, 3} {
+		formatter := linter.New("format", []linter.NamedChecker{{Name: "whitespace", Checker: checkers.Whitespace{}}})
+		formatter.Context = context
+		_, err := formatter.Format(toLines(content))
+		require.NoError(t, err)
+
+		streamer := linter.New("stream", []linter.NamedChecker{{Name: "whitespace", Checker: checkers.Whitespace{}}})
+		streamer.Context = context
+		require.NoError(t, streamer.Stream(strings.NewReader(content), nil))
+		require.NoError(t, streamer.Capture(strings.NewReader(content)))
+
+		require.Equal(t, formatter.Source, streamer.Source, "context %d", context)
+		require.Len(t, streamer.Source, min(2*(2*context+1), 8), "context %d", context)
+	}
+}
+
+// toLines splits the content into lines.
+func toLines(content string) [][]byte {
+	var lines [][]byte
+	for _, line := range strings.Split(content, "\n") {
+		lines = append(lines, []byte(line))
+	}
+
+	return lines
+}
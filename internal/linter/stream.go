@@ -0,0 +1,273 @@
+package linter
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// ErrNotStreamable is returned when streaming with checkers that do not implement the Streamer interface.
+var ErrNotStreamable = errors.New("not all checkers support streaming")
+
+// Stream reads the lines from r, checks them with the checkers and writes the lines with the edits of the
+// issues applied to w, like a single pass of Format. If w is nil, the resulting lines are discarded, which is
+// sufficient for linting. Only the lines held back by the checkers are kept in memory.
+func (l *Linter) Stream(r io.Reader, w io.Writer) error {
+	if !l.HasCheckers() {
+		panic("no checkers configured")
+	}
+
+	if !l.Streamable() {
+		return ErrNotStreamable
+	}
+
+	streams := make([]Stream, 0, len(l.Checkers))
+
+	for _, checker := range l.Checkers {
+		streams = append(streams, checker.Checker.(Streamer).Stream()) //nolint:forcetypeassert // Checked by Streamable.
+	}
+
+	for _, checker := range l.Checkers {
+		delete(l.Issues, checker.Name)
+		delete(l.Omitted, checker.Name)
+		delete(l.Dropped, checker.Name)
+		delete(l.Remaining, checker.Name)
+	}
+
+	out := newLineWriter(w)
+	window := newWindow(len(streams))
+
+	in := newLineReader(r)
+
+	for {
+		line, err := in.next()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("reading lines: %w", err)
+		}
+
+		window.push(line)
+
+		held := 0
+
+		for i, stream := range streams {
+			found := stream.Next(line)
+			l.collect(l.Checkers[i].Name, found)
+			l.remain(l.Checkers[i].Name, found)
+			window.add(i, Edits(found))
+
+			held = max(held, stream.Held())
+		}
+
+		window.flush(held, out.write)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	for i, stream := range streams {
+		found := stream.Close()
+		l.collect(l.Checkers[i].Name, found)
+		l.remain(l.Checkers[i].Name, found)
+		window.add(i, Edits(found))
+	}
+
+	window.close(out.write)
+
+	for i, dropped := range window.dropped {
+		if dropped > 0 {
+			l.Dropped[l.Checkers[i].Name] = dropped
+		}
+	}
+
+	return out.flush()
+}
+
+// window holds back the most recent lines, along with the edits of each checker, until no checker can edit
+// them anymore.
+type window struct {
+	// first is the number of the first line held back.
+	first int
+	lines [][]byte
+	// edits contains the edits of each checker.
+	edits [][]Edit
+	// dropped counts the edits of each checker not applied, as they conflict with the edits of earlier checkers.
+	dropped []int
+}
+
+func newWindow(checkers int) *window {
+	return &window{first: 1, edits: make([][]Edit, checkers), dropped: make([]int, checkers)}
+}
+
+// push holds back a copy of the next line.
+func (w *window) push(line []byte) {
+	w.lines = append(w.lines, bytes.Clone(line))
+}
+
+// add adds the edits of a checker.
+func (w *window) add(checker int, edits []Edit) {
+	w.edits[checker] = append(w.edits[checker], edits...)
+}
+
+// flush passes the lines before the held most recent ones on to emit, with their edits applied.
+func (w *window) flush(held int, emit func([]byte)) {
+	for len(w.lines) > held {
+		var (
+			edits  []Edit
+			owners []int
+		)
+
+		for checker := range w.edits {
+			taken := w.take(checker, w.first)
+			edits = append(edits, taken...)
+			owners = append(owners, slices.Repeat([]int{checker}, len(taken))...)
+		}
+
+		// An edit removing the whole line along with its newline drops it, other edits apply within the line.
+		if !slices.ContainsFunc(edits, func(edit Edit) bool { return removesLine(edit, w.first) }) {
+			line, conflicts := ApplyLine(w.first, w.lines[0], edits)
+			w.drop(owners, conflicts)
+			emit(line)
+		}
+
+		w.lines = w.lines[1:]
+		w.first++
+	}
+}
+
+// removesLine returns true if the edit removes the whole line with the given number, along with its newline.
+func removesLine(edit Edit, line int) bool {
+	return edit == Edit{Line: line, Column: 1, EndLine: line + 1, EndColumn: 1}
+}
+
+// take removes and returns the edits of a checker starting on the given line.
+// The edits of a checker are ordered by line, as they are collected line by line.
+func (w *window) take(checker, line int) []Edit {
+	edits := w.edits[checker]
+
+	n := 0
+	for n < len(edits) && edits[n].Line == line {
+		n++
+	}
+
+	w.edits[checker] = edits[n:]
+
+	return edits[:n]
+}
+
+// drop counts the conflicting edits, given by index, against the checkers owning them.
+func (w *window) drop(owners, conflicts []int) {
+	for _, index := range conflicts {
+		w.dropped[owners[index]]++
+	}
+}
+
+// close passes the remaining lines on to emit, with the edits applied.
+func (w *window) close(emit func([]byte)) {
+	var (
+		edits  []Edit
+		owners []int
+	)
+
+	for checker, checkerEdits := range w.edits {
+		for _, edit := range checkerEdits {
+			edit.Line -= w.first - 1
+			edit.EndLine -= w.first - 1
+			edits = append(edits, edit)
+			owners = append(owners, checker)
+		}
+	}
+
+	lines, conflicts := Apply(w.lines, edits)
+	w.drop(owners, conflicts)
+
+	for _, line := range lines {
+		emit(line)
+	}
+}
+
+// lineReader reads lines from a buffered reader, reusing its buffer.
+// The lines are split on "\n" in the same way as bytes.Split, i.e. the last line is
+// whatever follows the last "\n", which may be empty.
+type lineReader struct {
+	reader *bufio.Reader
+	buffer []byte
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{reader: bufio.NewReader(r)}
+}
+
+// next returns the next line, without the delimiter. The line is valid until the next call.
+// It returns io.EOF along with the last line.
+func (r *lineReader) next() ([]byte, error) {
+	line, err := r.reader.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		// The line is longer than the buffer, accumulate it.
+		r.buffer = append(r.buffer[:0], line...)
+
+		for errors.Is(err, bufio.ErrBufferFull) {
+			line, err = r.reader.ReadSlice('\n')
+			r.buffer = append(r.buffer, line...)
+		}
+
+		line = r.buffer
+	}
+
+	if err != nil {
+		return line, err
+	}
+
+	return line[:len(line)-1], nil
+}
+
+// lineWriter writes lines to a buffered writer, separating them with "\n".
+// Writing to a nil writer discards the lines.
+type lineWriter struct {
+	writer *bufio.Writer
+	first  bool
+	err    error
+}
+
+func newLineWriter(w io.Writer) *lineWriter {
+	if w == nil {
+		return &lineWriter{}
+	}
+
+	return &lineWriter{writer: bufio.NewWriter(w), first: true}
+}
+
+func (w *lineWriter) write(line []byte) {
+	if w.writer == nil || w.err != nil {
+		return
+	}
+
+	if !w.first {
+		w.err = w.writer.WriteByte('\n')
+	}
+
+	w.first = false
+
+	if w.err == nil {
+		_, w.err = w.writer.Write(line)
+	}
+}
+
+func (w *lineWriter) flush() error {
+	if w.writer == nil {
+		return nil
+	}
+
+	if w.err != nil {
+		return fmt.Errorf("writing lines: %w", w.err)
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("writing lines: %w", err)
+	}
+
+	return nil
+}
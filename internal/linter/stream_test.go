@@ -0,0 +1,153 @@
+package linter_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/linter"
+	"github.com/idelchi/wslint/pkg/prose"
+)
+
+// TestLinter_Stream verifies that streaming the content through each checker, and through the full pipeline,
+// produces the same lines and issues as formatting it in memory, both for a single pass and up to the fixed point.
+func TestLinter_Stream(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string // Name of the test case (for logging)
+		content string // Content to stream
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:    "clean",
+			content: "clean\n",
+		},
+		{
+			name:    "missing blank line",
+			content: "line",
+		},
+		{
+			name:    "trailing whitespace and blank lines",
+			content: "line \t\n\n \n\t\n",
+		},
+		{
+			name:    "blank lines in between",
+			content: "line\n\n\nline\n\n",
+		},
+		{
+			name:    "line holding only a repeated word",
+			content: "configure\nthe\n\nthe server \n",
+		},
+		{
+			name:    "only blank lines",
+			content: "\n\n\n",
+		},
+		{
+			name:    "long lines",
+			content: strings.Repeat("x", 10000) + " \n" + strings.Repeat("y", 5000) + "\n\n",
+		},
+		{
+			name:    "stutters",
+			content: "the the line \nand and\n",
+		},
+		{
+			name:    "stutters in comments",
+			content: "x x // the\n\n// the /* a a\nb b */ \"c c\"\n",
+		},
+		{
+			name:    "stutters across lines",
+			content: "configure the \n\n \nthe server and\nand the the\nthe end\n\n",
+		},
+		{
+			name:    "shebang",
+			content: "#!/bin/sh\n\n\necho \n",
+		},
+		{
+			name:    "header",
+			content: "\n# SPDX-License-Identifier: MIT\nline\n",
+		},
+	}
+
+	pipeline := []linter.NamedChecker{
+		{Name: "stutter", Checker: checkers.Stutter{}},
+		{Name: "whitespace", Checker: checkers.Whitespace{}},
+		{Name: "blanks", Checker: checkers.Blanks{}},
+	}
+
+	pipelines := map[string][]linter.NamedChecker{"pipeline": pipeline}
+	for _, checker := range pipeline {
+		pipelines[checker.Name] = []linter.NamedChecker{checker}
+	}
+
+	pipelines["header"] = []linter.NamedChecker{{
+		Name:    "header",
+		Checker: checkers.Header{Template: "SPDX-License-Identifier: MIT", Comment: "# ", Within: 2, Year: 2026},
+	}}
+	pipelines["stutter (go)"] = []linter.NamedChecker{{Name: "stutter", Checker: checkers.Stutter{Syntax: prose.Go}}}
+
+	for name, checkers := range pipelines {
+		for _, tc := range tcs {
+			t.Run(name+": "+tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				newLinter := func() *linter.Linter {
+					return linter.New(tc.name, checkers)
+				}
+
+				formatter := newLinter()
+				lines, err := formatter.Format(bytes.Split([]byte(tc.content), []byte("\n")))
+				require.NoError(t, err)
+
+				formatted := bytes.Join(lines, []byte("\n"))
+
+				streamer := newLinter()
+				require.True(t, streamer.Streamable())
+
+				var streamed bytes.Buffer
+				require.NoError(t, streamer.Stream(strings.NewReader(tc.content), &streamed))
+
+				require.Equal(t, string(pass(checkers, tc.content)), streamed.String(), "content differs")
+				require.Equal(t, formatter.Issues, streamer.Issues, "issues differ")
+
+				fixer := newLinter()
+
+				result, _, err := fixer.StreamFixed(strings.NewReader(tc.content), t.TempDir())
+				require.NoError(t, err)
+
+				t.Cleanup(func() { _ = result.Close() })
+
+				fixed, err := io.ReadAll(result)
+				require.NoError(t, err)
+
+				require.Equal(t, string(formatted), string(fixed), "fixed content differs")
+				require.Equal(t, formatter.Issues, fixer.Issues, "issues differ when fixing")
+
+				// Linting only must report the same issues.
+				linted := newLinter()
+				require.NoError(t, linted.Stream(strings.NewReader(tc.content), nil))
+				require.Equal(t, formatter.Issues, linted.Issues, "issues differ when linting")
+			})
+		}
+	}
+}
+
+// pass applies the edits of a single pass of the checkers to the content.
+func pass(checkers []linter.NamedChecker, content string) []byte {
+	lines := bytes.Split([]byte(content), []byte("\n"))
+
+	var edits []linter.Edit
+	for _, checker := range checkers {
+		edits = append(edits, linter.Edits(checker.Checker.Check(lines))...)
+	}
+
+	fixed, _ := linter.Apply(lines, edits)
+
+	return bytes.Join(fixed, []byte("\n"))
+}
@@ -16,17 +16,30 @@ import (
 func usage() {
 	log.Println("wslint checks or fixes files with trailing whitespaces and enforces final newlines")
 	log.Println("Usage: wslint [flags] [path ...]")
+	log.Println("       wslint hook install|run [flags]")
+	log.Println("       wslint commit-msg [flags] <file>")
 	flag.PrintDefaults()
 }
 
 // Run is the main function of the application.
 func Run(version string) int {
 	// Create the Wslint instance
-	wslint := wslint.Wslint{Usage: usage, Version: version}
-	wslint.Parse()
+	app := wslint.Wslint{Usage: usage, Version: version}
 
-	if wslint.Options.Experimental {
-		if wslint.Options.Fix {
+	// The hook subcommand installs, or runs as, the git pre-commit hook
+	if len(os.Args) > 1 && os.Args[1] == "hook" {
+		return app.Hook(os.Args[2:])
+	}
+
+	// The commit-msg subcommand lints commit messages, e.g. in the commit-msg hook
+	if len(os.Args) > 1 && os.Args[1] == "commit-msg" {
+		return app.CommitMsg(os.Args[2:])
+	}
+
+	app.Parse()
+
+	if app.Options.Experimental {
+		if app.Options.Fix {
 			log.Println(color.YellowString("Experimental feature may not work as expected"))
 			log.Println(color.YellowString("Press [enter] to continue or [ctrl+c] to abort"))
 
@@ -46,16 +59,26 @@ func Run(version string) int {
 			case <-sigCh:
 				log.Println("Ctrl+C was pressed. Aborting...")
 
-				return 0
+				return wslint.ExitClean
 			case <-enterCh:
 				log.Println("Enter was pressed. Continuing...")
 			}
 		}
 	}
 
-	if wslint.Match(); len(wslint.Files) == 0 {
-		return 1
+	if err := app.Match(); err != nil {
+		log.Printf("Error: %v", err)
+
+		return wslint.MatchExitCode(err)
+	}
+
+	if len(app.Files) == 0 {
+		if app.Options.FailOnNoFiles {
+			return wslint.ExitNoFiles
+		}
+
+		return wslint.ExitClean
 	}
 
-	return wslint.Process()
+	return app.Process()
 }
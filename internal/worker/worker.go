@@ -14,19 +14,23 @@
 package worker
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"os"
-	"strings"
+	"slices"
 	"sync"
 	"time"
 
-	"golang.org/x/exp/slices"
-
-	"github.com/natefinch/atomic"
-
 	"github.com/idelchi/wslint/internal/linter"
+	"github.com/idelchi/wslint/internal/writer"
 )
 
+// ErrSkipped is returned by a Source for content that is not to be linted (e.g. binary content).
+var ErrSkipped = errors.New("file skipped")
+
 // Pool represents a pool of workers.
 type Pool struct {
 	// The number of workers in the pool
@@ -37,6 +41,17 @@ type Pool struct {
 	Logger *log.Logger
 	// Fix
 	Fix bool
+	// Writer writes the fixed files
+	Writer writer.Writer
+	// Lock takes an advisory lock on each file while it is processed
+	Lock bool
+	// Verify relints the fixed content and refuses to write it if a checker would still change it or report errors in it
+	Verify bool
+	// Diff records the unified diff of the fixes
+	Diff bool
+	// Source, if set, reads the content of the files instead of the file system (e.g. from a git tree).
+	// The files are then processed in memory, and never written.
+	Source func(name string) ([]byte, error)
 	// Files
 	Files []linter.Linter
 	// Time spent processing the files
@@ -44,6 +59,9 @@ type Pool struct {
 }
 
 // Start the worker pool.
+// The results are sent in the order of completion, and the results channel is closed once all jobs
+// are processed. Start returns as soon as all jobs are dispatched, so that the results can be consumed
+// while the remaining jobs are processed.
 func (p *Pool) Start(jobs, results chan linter.Linter) {
 	// Create a wait group to ensure all workers have finished
 	var waitGroup sync.WaitGroup
@@ -54,7 +72,7 @@ func (p *Pool) Start(jobs, results chan linter.Linter) {
 
 		go func() {
 			defer waitGroup.Done()
-			worker(i+1, p.Logger, p.Fix, jobs, results)
+			p.worker(i+1, jobs, results)
 		}()
 	}
 
@@ -68,11 +86,15 @@ func (p *Pool) Start(jobs, results chan linter.Linter) {
 
 	close(jobs)
 
-	// Wait for all the workers to finish
-	waitGroup.Wait()
+	go func() {
+		// Wait for all the workers to finish
+		waitGroup.Wait()
 
-	// Measure the time it takes to process all the files
-	p.ProcessingTime = time.Since(start)
+		// Measure the time it takes to process all the files
+		p.ProcessingTime = time.Since(start)
+
+		close(results)
+	}()
 }
 
 // Stats prints the stats of the worker pool run.
@@ -82,48 +104,183 @@ func (p *Pool) Stats() {
 
 // worker processes jobs.
 // https://twin.sh/articles/39/go-concurrency-goroutines-worker-pools-and-throttling-made-simple
-func worker(
-	identifier int,
-	logger *log.Logger,
-	fix bool,
-	files <-chan linter.Linter,
-	results chan<- linter.Linter,
-) {
+func (p *Pool) worker(identifier int, files <-chan linter.Linter, results chan<- linter.Linter) {
 	jobsProcessed := 0
 
 	for file := range files {
-		logger.Printf("<processing> %q", file.Name)
+		p.Logger.Printf("<processing> %q", file.Name)
+
+		file.Error = p.process(&file)
+
+		results <- file
+
+		jobsProcessed++
+	}
 
-		func() {
-			// TODO(Idelchi): Work with []byte instead of string
-			content, err := os.ReadFile(file.Name)
-			if err != nil {
-				panic("failed to read file")
-			}
+	p.Logger.Printf("<worker %d> processed %d jobs", identifier, jobsProcessed)
+}
 
-			src := strings.Split(string(content), "\n")
-			res := make([]string, len(src))
-			copy(res, src)
+// process formats a single file and writes the result back if fixing is enabled and the content changed.
+func (p *Pool) process(file *linter.Linter) error {
+	if p.Lock {
+		unlock, err := writer.Lock(file.Name)
+		if err != nil {
+			return err
+		}
 
-			res = file.Format(res)
+		defer func() { _ = unlock() }()
+	}
 
-			if !slices.Equal(src, res) && fix {
-				info, _ := os.Lstat(file.Name)
+	var err error
 
-				if err := atomic.WriteFile(file.Name, strings.NewReader(strings.Join(res, "\n"))); err != nil {
-					panic("failed to write file")
-				}
+	// The diff needs both the original and the fixed content in memory.
+	if file.Streamable() && !p.Diff && p.Source == nil {
+		err = p.stream(file)
+	} else {
+		err = p.format(file)
+	}
 
-				if err = os.Chmod(file.Name, info.Mode()); err != nil {
-					panic("failed to change file permissions")
-				}
-			}
-		}()
+	switch {
+	case errors.Is(err, writer.ErrHardLinked):
+		log.Printf("Warning: %q not fixed: %v", file.Name, err)
 
-		results <- file
+		return nil
+	case errors.Is(err, ErrSkipped):
+		return nil
+	}
 
-		jobsProcessed++
+	return err
+}
+
+// stream streams the file through the checkers, without loading it into memory.
+// If issues are found and fixing is enabled, the file is streamed through the pipeline until it stops
+// changing, into a temporary file which then replaces it.
+func (p *Pool) stream(file *linter.Linter) error {
+	source, err := writer.Open(file.Name)
+	if err != nil {
+		return err
+	}
+
+	defer source.Close()
+
+	if err := file.Stream(source, nil); err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	// The conflicts between the edits of a single pass are resolved by the later passes of StreamFixed.
+	clear(file.Dropped)
+
+	if !file.HasIssues() {
+		return nil
+	}
+
+	in, err := os.Open(file.Name)
+	if err != nil {
+		return fmt.Errorf("opening file: %w", err)
+	}
+
+	defer in.Close()
+
+	// The issues are only known once the whole file is read, so their source lines are read in a second pass.
+	if err := file.Capture(in); err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	if !p.Fix {
+		return nil
+	}
+
+	if _, err := in.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewinding file: %w", err)
 	}
 
-	logger.Printf("<worker %d> processed %d jobs", identifier, jobsProcessed)
+	result, changed, err := file.StreamFixed(in, "")
+	if err != nil {
+		return fmt.Errorf("fixing file: %w", err)
+	}
+
+	defer func() {
+		_ = result.Close()
+		_ = os.Remove(result.Name())
+	}()
+
+	if !changed {
+		return nil
+	}
+
+	if p.Verify {
+		if err := file.VerifyStream(func() (io.ReadCloser, error) { return os.Open(result.Name()) }); err != nil {
+			return err //nolint:wrapcheck // The error is self-explanatory.
+		}
+	}
+
+	err = p.Writer.WriteFunc(file.Name, source.Snapshot(), func(out io.Writer) error {
+		_, err := io.Copy(out, result)
+
+		return err //nolint:wrapcheck // The error is wrapped by the writer.
+	})
+	if err != nil {
+		return err
+	}
+
+	file.Fixed = true
+
+	return nil
+}
+
+// format reads the whole file into memory and formats it.
+func (p *Pool) format(file *linter.Linter) error {
+	content, snapshot, err := p.read(file.Name)
+	if err != nil {
+		return err
+	}
+
+	src := bytes.Split(content, []byte("\n"))
+	res, converged := file.Format(src)
+
+	if slices.EqualFunc(src, res, bytes.Equal) {
+		return nil
+	}
+
+	fixed := bytes.Join(res, []byte("\n"))
+
+	if p.Diff {
+		if file.Diff, err = linter.UnifiedDiff(file.Name, content, fixed); err != nil {
+			return err //nolint:wrapcheck // The error is self-explanatory.
+		}
+	}
+
+	if !p.Fix || p.Source != nil {
+		return nil
+	}
+
+	// Content whose fixes do not converge is not written.
+	if converged != nil {
+		return converged //nolint:wrapcheck // The error is self-explanatory.
+	}
+
+	if p.Verify {
+		if err := file.Verify(res); err != nil {
+			return err //nolint:wrapcheck // The error is self-explanatory.
+		}
+	}
+
+	if err := p.Writer.WriteFile(file.Name, fixed, snapshot); err != nil {
+		return err
+	}
+
+	file.Fixed = true
+
+	return nil
+}
+
+// read reads the whole file, from the Source if set.
+func (p *Pool) read(name string) ([]byte, writer.Snapshot, error) {
+	if p.Source == nil {
+		return writer.Read(name) //nolint:wrapcheck // The error is wrapped by the writer.
+	}
+
+	content, err := p.Source(name)
+
+	return content, writer.Snapshot{}, err
 }
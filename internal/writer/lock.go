@@ -0,0 +1,15 @@
+package writer
+
+import (
+	"errors"
+)
+
+// LockSuffix is appended to the name of a file to get the name of the sidecar file locked for it.
+const LockSuffix = ".lock"
+
+var (
+	// ErrLocked is returned when a file is locked by another process.
+	ErrLocked = errors.New("file is locked by another process")
+	// ErrLockUnsupported is returned when advisory locks are not supported on the platform.
+	ErrLockUnsupported = errors.New("advisory file locks are not supported on this platform")
+)
@@ -0,0 +1,8 @@
+//go:build !(linux || darwin)
+
+package writer
+
+// Lock is not supported on this platform and always returns ErrLockUnsupported.
+func Lock(string) (func() error, error) {
+	return nil, ErrLockUnsupported
+}
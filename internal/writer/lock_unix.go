@@ -0,0 +1,50 @@
+//go:build linux || darwin
+
+package writer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Lock acquires an exclusive advisory lock (flock) for the file with the given name, without blocking.
+// The lock is taken on the sidecar file "<name>.lock" rather than the file itself, as fixing replaces the file
+// with a new one, which other processes opening the path would lock instead. The sidecar file is created if
+// needed, and removed when the lock is released.
+// It returns ErrLocked if another process holds the lock.
+// The returned function releases the lock.
+func Lock(name string) (unlock func() error, err error) {
+	path := name + LockSuffix
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600) //nolint:mnd // Permissions of the sidecar file.
+	if err != nil {
+		return nil, fmt.Errorf("opening lock file: %w", err)
+	}
+
+	if err := unix.Flock(int(file.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		_ = file.Close()
+
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return nil, ErrLocked
+		}
+
+		return nil, fmt.Errorf("locking file: %w", err)
+	}
+
+	// The previous holder removes the sidecar file when releasing the lock. If it did so after the file was
+	// opened, the lock is on a file no other process can open, while another process may lock a new one.
+	locked, err := file.Stat()
+	if current, statErr := os.Stat(path); err != nil || statErr != nil || !os.SameFile(locked, current) {
+		_ = file.Close()
+
+		return nil, ErrLocked
+	}
+
+	return func() error {
+		// The sidecar file is removed while the lock is still held, and closing the file releases the lock.
+		return errors.Join(os.Remove(path), file.Close())
+	}, nil
+}
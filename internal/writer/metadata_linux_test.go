@@ -0,0 +1,32 @@
+//go:build linux
+
+package writer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/idelchi/wslint/internal/writer"
+)
+
+func TestWriter_WriteFile_Xattrs(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "test.txt")
+	require.NoError(t, os.WriteFile(file, []byte("old"), 0o600))
+
+	if err := unix.Setxattr(file, "user.wslint", []byte("value"), 0); err != nil {
+		t.Skipf("Skipping test, extended attributes not supported: %v", err)
+	}
+
+	require.NoError(t, write(t, writer.Writer{}, file, "new"))
+
+	value := make([]byte, 16)
+	size, err := unix.Getxattr(file, "user.wslint", value)
+	require.NoError(t, err)
+	require.Equal(t, "value", string(value[:size]), "extended attributes must be preserved")
+}
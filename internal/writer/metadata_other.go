@@ -0,0 +1,17 @@
+//go:build !(linux || darwin)
+
+package writer
+
+import (
+	"io/fs"
+)
+
+// links returns the number of hard links to the file, which is not detected on this platform.
+func links(fs.FileInfo) uint64 {
+	return 1
+}
+
+// preserve is a no-op on this platform, where only the permissions are preserved.
+func preserve(string, string, fs.FileInfo) error {
+	return nil
+}
@@ -0,0 +1,108 @@
+//go:build linux || darwin
+
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// links returns the number of hard links to the file.
+func links(info fs.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Nlink) //nolint:unconvert // Nlink is uint16 on darwin.
+	}
+
+	return 1
+}
+
+// preserve copies the ownership and the extended attributes of the original file to the replacement.
+// Failures due to missing permissions or support are ignored.
+func preserve(original, replacement string, info fs.FileInfo) error {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		if err := os.Lchown(replacement, int(stat.Uid), int(stat.Gid)); err != nil && !ignorable(err) {
+			return fmt.Errorf("changing file ownership: %w", err)
+		}
+	}
+
+	names, err := xattrs(original)
+	if err != nil {
+		if ignorable(err) {
+			return nil
+		}
+
+		return fmt.Errorf("listing extended attributes: %w", err)
+	}
+
+	for _, name := range names {
+		value, err := xattr(original, name)
+		if err != nil {
+			if ignorable(err) {
+				continue
+			}
+
+			return fmt.Errorf("reading extended attribute %q: %w", name, err)
+		}
+
+		if err := unix.Setxattr(replacement, name, value, 0); err != nil && !ignorable(err) {
+			return fmt.Errorf("setting extended attribute %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// xattrs lists the names of the extended attributes of the file.
+func xattrs(name string) ([]string, error) {
+	size, err := unix.Listxattr(name, nil)
+	if err != nil || size == 0 {
+		return nil, err
+	}
+
+	buffer := make([]byte, size)
+
+	if size, err = unix.Listxattr(name, buffer); err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, name := range bytes.Split(buffer[:size], []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+
+	return names, nil
+}
+
+// xattr reads the value of an extended attribute of the file.
+func xattr(name, attribute string) ([]byte, error) {
+	size, err := unix.Getxattr(name, attribute, nil)
+	if err != nil || size == 0 {
+		return nil, err
+	}
+
+	value := make([]byte, size)
+
+	if size, err = unix.Getxattr(name, attribute, value); err != nil {
+		return nil, err
+	}
+
+	return value[:size], nil
+}
+
+// ignorable returns true for errors caused by missing permissions or support,
+// in which case the metadata is preserved on a best-effort basis.
+func ignorable(err error) bool {
+	return errors.Is(err, unix.EPERM) ||
+		errors.Is(err, unix.EACCES) ||
+		errors.Is(err, unix.ENOTSUP) ||
+		errors.Is(err, unix.EOPNOTSUPP)
+}
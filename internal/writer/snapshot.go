@@ -0,0 +1,114 @@
+package writer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// ErrModified is returned when a file was modified between reading and writing it.
+var ErrModified = errors.New("file was modified since it was read, not overwriting")
+
+// Snapshot records the state of a file at the time it was read.
+type Snapshot struct {
+	size    int64
+	modTime time.Time
+	hash    [sha256.Size]byte
+}
+
+// Source reads a file while recording a snapshot of its state.
+type Source struct {
+	file *os.File
+	info fs.FileInfo
+	hash hash.Hash
+}
+
+// Open opens the file with the given name for reading.
+// Once the file has been read to the end, Snapshot returns its state, to be passed to WriteFile
+// in order to detect modifications made in the meantime.
+func Open(name string) (*Source, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("getting file info: %w", err)
+	}
+
+	return &Source{file: file, info: info, hash: sha256.New()}, nil
+}
+
+// Read reads from the file, hashing the content read.
+func (s *Source) Read(p []byte) (int, error) {
+	n, err := s.file.Read(p)
+	s.hash.Write(p[:n])
+
+	return n, err //nolint:wrapcheck // io.Reader errors must not be wrapped.
+}
+
+// Close closes the file.
+func (s *Source) Close() error {
+	return s.file.Close() //nolint:wrapcheck // Closing errors are self-explanatory.
+}
+
+// Snapshot returns the state of the file. It is only complete once the file has been read to the end.
+func (s *Source) Snapshot() Snapshot {
+	snapshot := Snapshot{size: s.info.Size(), modTime: s.info.ModTime()}
+	s.hash.Sum(snapshot.hash[:0])
+
+	return snapshot
+}
+
+// Read reads the content of the file with the given name, along with a snapshot of its state.
+// The snapshot is passed to WriteFile, to detect modifications made in the meantime.
+func Read(name string) ([]byte, Snapshot, error) {
+	source, err := Open(name)
+	if err != nil {
+		return nil, Snapshot{}, err
+	}
+
+	defer source.Close()
+
+	content, err := io.ReadAll(source)
+	if err != nil {
+		return nil, Snapshot{}, fmt.Errorf("reading file: %w", err)
+	}
+
+	return content, source.Snapshot(), nil
+}
+
+// verify returns ErrModified if the file no longer matches the snapshot.
+// The size and modification time are compared first, and the content hash is used to catch
+// modifications within the resolution of the file system timestamps.
+func (s Snapshot) verify(name string) error {
+	source, err := Open(name)
+	if err != nil {
+		return err
+	}
+
+	defer source.Close()
+
+	if source.info.Size() != s.size || !source.info.ModTime().Equal(s.modTime) {
+		return ErrModified
+	}
+
+	if _, err := io.Copy(io.Discard, source); err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	if current := source.Snapshot(); !bytes.Equal(current.hash[:], s.hash[:]) {
+		return ErrModified
+	}
+
+	return nil
+}
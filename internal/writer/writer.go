@@ -0,0 +1,183 @@
+// Package writer replaces the content of files, while preserving their metadata.
+//
+// Files are written atomically, by writing to a temporary file in the same directory and renaming it
+// over the original. Before the rename, the permissions, ownership and extended attributes (which
+// include POSIX ACLs on Linux) of the original are applied to the temporary file, where permitted.
+//
+// Symbolic links are resolved, and the content is written to their target, leaving the link in place.
+// Hard-linked files cannot be replaced by a rename without breaking the link, so they are either
+// updated in place or skipped, depending on the configured policy.
+package writer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/natefinch/atomic"
+)
+
+// ErrHardLinked is returned when a hard-linked file is skipped.
+var ErrHardLinked = errors.New("file has multiple hard links, skipped")
+
+// HardLinks is the policy for writing to files with multiple hard links.
+type HardLinks string
+
+const (
+	// InPlace overwrites hard-linked files in place, which keeps all links intact but is not atomic.
+	InPlace HardLinks = "inplace"
+	// Skip leaves hard-linked files untouched and returns ErrHardLinked.
+	Skip HardLinks = "skip"
+)
+
+// ErrInvalidPolicy is returned when parsing an unknown hard link policy.
+var ErrInvalidPolicy = errors.New("invalid hard link policy")
+
+// ParseHardLinks parses a hard link policy.
+func ParseHardLinks(policy string) (HardLinks, error) {
+	switch HardLinks(policy) {
+	case InPlace, Skip:
+		return HardLinks(policy), nil
+	default:
+		return "", fmt.Errorf("%w: %q, must be one of %q or %q", ErrInvalidPolicy, policy, InPlace, Skip)
+	}
+}
+
+// Writer writes files according to its policies.
+type Writer struct {
+	// HardLinks is the policy for hard-linked files. Defaults to InPlace.
+	HardLinks HardLinks
+}
+
+// WriteFile replaces the content of the file with the given name.
+// Symbolic links are followed, and the metadata of the file is preserved where permitted.
+// The original snapshot, as returned by Read, is verified right before the file is replaced,
+// and ErrModified is returned if the file was changed in the meantime.
+func (w Writer) WriteFile(name string, content []byte, original Snapshot) error {
+	return w.WriteFunc(name, original, func(out io.Writer) error {
+		_, err := out.Write(content)
+
+		return err //nolint:wrapcheck // The error is wrapped by the caller.
+	})
+}
+
+// WriteFunc is like WriteFile, but the content is produced by the write function.
+// The original file remains untouched until the write function returns, so it can be
+// streamed from the original.
+func (w Writer) WriteFunc(name string, original Snapshot, write func(io.Writer) error) error {
+	target, err := filepath.EvalSymlinks(name)
+	if err != nil {
+		return fmt.Errorf("resolving symbolic links: %w", err)
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return fmt.Errorf("getting file info: %w", err)
+	}
+
+	if links(info) > 1 && w.HardLinks == Skip {
+		return ErrHardLinked
+	}
+
+	temp, err := produce(target, write)
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(temp)
+
+	if links(info) > 1 {
+		return inPlace(target, temp, original)
+	}
+
+	return replace(target, temp, info, original)
+}
+
+// produce writes the content to a temporary file next to the target and returns its name.
+func produce(target string, write func(io.Writer) error) (name string, err error) {
+	temp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".wslint-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temporary file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(temp.Name())
+		}
+	}()
+
+	if err = write(temp); err != nil {
+		_ = temp.Close()
+
+		return "", fmt.Errorf("writing temporary file: %w", err)
+	}
+
+	if err = temp.Sync(); err != nil {
+		_ = temp.Close()
+
+		return "", fmt.Errorf("syncing temporary file: %w", err)
+	}
+
+	if err = temp.Close(); err != nil {
+		return "", fmt.Errorf("closing temporary file: %w", err)
+	}
+
+	return temp.Name(), nil
+}
+
+// inPlace truncates and overwrites the file with the content of the temporary file,
+// keeping its inode and therefore all of its metadata.
+func inPlace(name, temp string, original Snapshot) error {
+	if err := original.verify(name); err != nil {
+		return err
+	}
+
+	source, err := os.Open(temp)
+	if err != nil {
+		return fmt.Errorf("opening temporary file: %w", err)
+	}
+
+	defer source.Close()
+
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return fmt.Errorf("opening file: %w", err)
+	}
+
+	if _, err := io.Copy(file, source); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing file: %w", err)
+	}
+
+	return nil
+}
+
+// replace copies the metadata of the original onto the temporary file, and renames it over the original.
+func replace(name, temp string, info fs.FileInfo, original Snapshot) error {
+	// Ownership must be restored before the permissions, as changing the owner clears the setuid & setgid bits.
+	if err := preserve(name, temp, info); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(temp, info.Mode()&(fs.ModePerm|fs.ModeSetuid|fs.ModeSetgid|fs.ModeSticky)); err != nil {
+		return fmt.Errorf("changing file permissions: %w", err)
+	}
+
+	if err := original.verify(name); err != nil {
+		return err
+	}
+
+	if err := atomic.ReplaceFile(temp, name); err != nil {
+		return fmt.Errorf("replacing file: %w", err)
+	}
+
+	return nil
+}
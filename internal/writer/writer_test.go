@@ -0,0 +1,196 @@
+package writer_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/writer"
+)
+
+// skipOnWindows skips tests relying on symbolic links, hard links or unix permissions.
+func skipOnWindows(t *testing.T) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on windows")
+	}
+}
+
+// write reads the file to take a snapshot and writes the content to it.
+func write(t *testing.T, w writer.Writer, name, content string) error {
+	t.Helper()
+
+	_, snapshot, err := writer.Read(name)
+	require.NoError(t, err)
+
+	return w.WriteFile(name, []byte(content), snapshot)
+}
+
+func TestWriter_WriteFile(t *testing.T) {
+	t.Parallel()
+
+	skipOnWindows(t)
+
+	file := filepath.Join(t.TempDir(), "test.txt")
+	require.NoError(t, os.WriteFile(file, []byte("old"), 0o640))
+	require.NoError(t, os.Chmod(file, 0o751))
+
+	require.NoError(t, write(t, writer.Writer{}, file, "new"))
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "new", string(content))
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o751), info.Mode().Perm(), "permissions must be preserved")
+
+	entries, err := os.ReadDir(filepath.Dir(file))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "no temporary files must be left behind")
+}
+
+func TestWriter_WriteFile_Symlink(t *testing.T) {
+	t.Parallel()
+
+	skipOnWindows(t)
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	link := filepath.Join(dir, "link.txt")
+
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0o600))
+	require.NoError(t, os.Symlink("target.txt", link))
+
+	require.NoError(t, write(t, writer.Writer{}, link, "new"))
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	require.Equal(t, os.ModeSymlink, info.Mode().Type(), "the symbolic link must be kept")
+
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "new", string(content), "the target must be written")
+}
+
+func TestWriter_WriteFile_HardLink(t *testing.T) {
+	t.Parallel()
+
+	skipOnWindows(t)
+
+	tcs := []struct {
+		name     string           // Name of the test case (for logging)
+		policy   writer.HardLinks // Policy for hard-linked files
+		err      error            // Error that should be returned
+		expected string           // Content expected in both links
+	}{
+		{
+			name:     "in place",
+			policy:   writer.InPlace,
+			expected: "new",
+		},
+		{
+			name:     "skip",
+			policy:   writer.Skip,
+			err:      writer.ErrHardLinked,
+			expected: "old",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			file := filepath.Join(dir, "file.txt")
+			link := filepath.Join(dir, "link.txt")
+
+			require.NoError(t, os.WriteFile(file, []byte("old"), 0o600))
+			require.NoError(t, os.Link(file, link))
+
+			err := write(t, writer.Writer{HardLinks: tc.policy}, file, "new")
+			require.ErrorIs(t, err, tc.err)
+
+			for _, name := range []string{file, link} {
+				content, err := os.ReadFile(name)
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, string(content), "content of %q", name)
+			}
+
+			fileInfo, err := os.Stat(file)
+			require.NoError(t, err)
+
+			linkInfo, err := os.Stat(link)
+			require.NoError(t, err)
+
+			require.True(t, os.SameFile(fileInfo, linkInfo), "the hard link must be kept")
+		})
+	}
+}
+
+func TestWriter_WriteFile_Modified(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "test.txt")
+	require.NoError(t, os.WriteFile(file, []byte("old"), 0o600))
+
+	_, snapshot, err := writer.Read(file)
+	require.NoError(t, err)
+
+	// Simulate an editor saving the file, keeping the size and the modification time.
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, []byte("odd"), 0o600))
+	require.NoError(t, os.Chtimes(file, info.ModTime(), info.ModTime()))
+
+	require.ErrorIs(t, writer.Writer{}.WriteFile(file, []byte("new"), snapshot), writer.ErrModified)
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "odd", string(content), "the newer content must be kept")
+}
+
+func TestLock(t *testing.T) {
+	t.Parallel()
+
+	skipOnWindows(t)
+
+	file := filepath.Join(t.TempDir(), "test.txt")
+	require.NoError(t, os.WriteFile(file, []byte("content"), 0o600))
+
+	unlock, err := writer.Lock(file)
+	require.NoError(t, err)
+	require.FileExists(t, file+writer.LockSuffix)
+
+	_, err = writer.Lock(file)
+	require.ErrorIs(t, err, writer.ErrLocked)
+
+	// Replacing the file (as fixing does) does not release the lock.
+	require.NoError(t, os.WriteFile(file+".new", []byte("fixed"), 0o600))
+	require.NoError(t, os.Rename(file+".new", file))
+
+	_, err = writer.Lock(file)
+	require.ErrorIs(t, err, writer.ErrLocked)
+
+	require.NoError(t, unlock())
+	require.NoFileExists(t, file+writer.LockSuffix)
+
+	unlock, err = writer.Lock(file)
+	require.NoError(t, err)
+	require.NoError(t, unlock())
+}
+
+func TestParseHardLinks(t *testing.T) {
+	t.Parallel()
+
+	policy, err := writer.ParseHardLinks("skip")
+	require.NoError(t, err)
+	require.Equal(t, writer.Skip, policy)
+
+	_, err = writer.ParseHardLinks("break")
+	require.ErrorIs(t, err, writer.ErrInvalidPolicy)
+}
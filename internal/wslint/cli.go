@@ -9,13 +9,20 @@ import (
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"text/tabwriter"
+
+	"github.com/idelchi/wslint/internal/config"
+	"github.com/idelchi/wslint/internal/writer"
+	"github.com/idelchi/wslint/pkg/stuttering"
+	"github.com/idelchi/wslint/pkg/typos"
+	api "github.com/idelchi/wslint/pkg/wslint"
 )
 
 // exit prints the message and exits with the specified exit code.
 func (w *Wslint) exit(code int, msg string) {
 	log.Println(msg)
 
-	if code != 0 {
+	if code == ExitUsage {
 		w.Usage()
 	}
 
@@ -37,8 +44,41 @@ type Options struct {
 	Verbose         bool
 	Experimental    bool
 	Interactive     bool
+	// Fail with ExitNoFiles if no files matched the patterns.
+	FailOnNoFiles bool
+	// Return ExitClean instead of ExitFixed if all issues were fixed.
+	ExitZeroOnFix bool
+	// Policy for fixing files with multiple hard links.
+	HardLinks writer.HardLinks
+	// Take an advisory lock on each file while processing it.
+	Lock bool
+	// Report each file as soon as it is processed, in input order, instead of sorted by path at the end.
+	StreamOutput bool
+	// Relint the fixed content and refuse to write it if a checker would still change it or report errors in it.
+	Verify bool
+	// Number of lines shown around each offending line.
+	Context int
+	// Show the unified diff of the fixes.
+	Diff bool
+	// Lint the files in the tree of this commit, instead of the file system.
+	Rev string
+	// Maximum number of words of a repeated phrase reported by the stutter checker.
+	StutterLength int
+	// Languages whose legitimate repetitions are not reported by the stutter checker.
+	StutterLanguages []string
+	// Repetitions not reported by the stutter checker, along with the paths they apply to.
+	StutterExceptions stuttering.Exceptions
+	// Words not reported by the typos checker.
+	TyposWords typos.Words
+	// User-defined rules, each reported under its own name.
+	Rules api.Rules
+	// Headers the files must start with, of which the first applying to a file is required.
+	Headers api.Headers
 }
 
+// defaultStutterLanguages are the languages whose legitimate repetitions are not reported by default.
+const defaultStutterLanguages = "en"
+
 // Parse collects the commandline arguments and returns them as a CLIOptions struct.
 //
 //nolint:funlen // This function is long, but has one dedicated function.
@@ -54,6 +94,21 @@ func (w *Wslint) Parse() {
 		quiet        = flag.Bool("q", false, "suppress messages")
 		experimental = flag.Bool("x", false, "enable experimental features")
 		interactive  = flag.Bool("i", false, "interactive mode")
+		failNoFiles  = flag.Bool("fail-on-no-files", false, "exit with a non-zero code if no files matched")
+		exitZeroFix  = flag.Bool("exit-zero-on-fix", false, "exit with zero if all issues were fixed")
+		hardLinks    = flag.String("hardlinks", string(writer.InPlace), "fix hard-linked files 'inplace' or 'skip' them")
+		lock         = flag.Bool("lock", false, "take an advisory lock on each file while processing it")
+		streamOutput = flag.Bool("stream-output", false, "report files as they are processed, in input order")
+		verify       = flag.Bool("verify", false, "relint fixed content and fail if any issue remains")
+		context      = flag.Int("context", 0, "number of lines shown around each offending line")
+		diff         = flag.Bool("diff", false, "show the unified diff of the fixes")
+		rev          = flag.String("rev", "", "lint the files in the tree of the commit instead of the file system")
+		stutterLen   = flag.Int("stutter-length", stuttering.DefaultLength, "maximum number of words of a repeated phrase")
+		languages    = flag.String("stutter-languages", defaultStutterLanguages, "languages of legitimate repetitions, comma separated")
+		exceptions   = flag.String("stutter-exceptions", "", "file listing the repetitions not reported")
+		typosWords   = flag.String("typos-words", "", "file listing the words not reported as misspelled")
+		configFile   = flag.String("config", "", "configuration file, defaults to "+config.Default+" if it exists")
+		list         = flag.Bool("list-checkers", false, "list the checkers, including the rules of the configuration")
 	)
 
 	// No time stamp in the log output
@@ -71,33 +126,137 @@ func (w *Wslint) Parse() {
 			}
 		}
 
-		w.exit(0, w.Version)
+		w.exit(ExitClean, w.Version)
 	// If no arguments are given, raise an error message
-	case flag.NArg() == 0:
-		w.exit(1, "Error: Need to provide at least one path element")
+	case flag.NArg() == 0 && !*list:
+		w.exit(ExitUsage, "Error: Need to provide at least one path element")
 	// If the number of parallel jobs is less than 1, raise an error message
 	case *parallel <= 0:
-		w.exit(1, "Error: Number of parallel jobs must be greater than 0")
+		w.exit(ExitUsage, "Error: Number of parallel jobs must be greater than 0")
+	// If the number of context lines is negative, raise an error message
+	case *context < 0:
+		w.exit(ExitUsage, "Error: Number of context lines must not be negative")
+	// If the length of repeated phrases is less than 1, raise an error message
+	case *stutterLen <= 0:
+		w.exit(ExitUsage, "Error: Length of repeated phrases must be greater than 0")
+	// Files in a commit are read-only
+	case *rev != "" && (*fix || *lock):
+		w.exit(ExitUsage, "Error: --rev is read-only and cannot be combined with -w or --lock")
 	// Interactive is not implemented yet
 	case *interactive:
-		w.exit(1, "Error: Interactive mode is not implemented yet")
+		w.exit(ExitUsage, "Error: Interactive mode is not implemented yet")
+	}
+
+	// Validate the languages
+	stutterLanguages := splitLanguages(*languages)
+	if _, err := stuttering.LanguageExceptions(stutterLanguages...); err != nil {
+		w.exit(ExitUsage, fmt.Sprintf("Error: %v", err))
+	}
+
+	// Validate the hard link policy
+	policy, err := writer.ParseHardLinks(*hardLinks)
+	if err != nil {
+		w.exit(ExitUsage, fmt.Sprintf("Error: %v", err))
+	}
+
+	verboseLog := loggers(*verbose, *quiet)
+
+	w.Options = Options{
+		Exclude:          splitExcludes(*exclude),
+		NumberOfWorkers:  *parallel,
+		Fix:              *fix,
+		Logger:           verboseLog,
+		Patterns:         flag.Args(),
+		Hidden:           *hidden,
+		Quiet:            *quiet,
+		Verbose:          *verbose,
+		Experimental:     *experimental,
+		Interactive:      *interactive,
+		FailOnNoFiles:    *failNoFiles,
+		ExitZeroOnFix:    *exitZeroFix,
+		HardLinks:        policy,
+		Lock:             *lock,
+		StreamOutput:     *streamOutput,
+		Verify:           *verify,
+		Context:          *context,
+		Diff:             *diff,
+		Rev:              *rev,
+		StutterLength:    *stutterLen,
+		StutterLanguages: stutterLanguages,
+	}
+
+	// Load the configuration file and the files it refers to
+	if err := w.Options.load(*configFile, *exceptions, *typosWords); err != nil {
+		w.exit(ExitUsage, fmt.Sprintf("Error: %v", err))
+	}
+
+	// If the list flag is set, print the checkers and exit
+	if *list {
+		w.exit(ExitClean, w.listCheckers())
+	}
+}
+
+// listCheckers lists the checkers in the order of the pipeline, along with whether they are experimental,
+// and the severity and message of the rules.
+func (w *Wslint) listCheckers() string {
+	rules := make(map[string]api.Rule, len(w.Options.Rules))
+	for _, rule := range w.Options.Rules {
+		rules[rule.Name] = rule
+	}
+
+	var list strings.Builder
+
+	table := tabwriter.NewWriter(&list, 0, 0, 2, ' ', 0) //nolint:mnd // Padding between the columns.
+
+	for _, checker := range api.Describe(w.config()) {
+		var notes []string
+
+		if checker.Experimental {
+			notes = append(notes, "experimental")
+		}
+
+		if rule, ok := rules[checker.Name]; checker.Rule && ok {
+			notes = append(notes, "rule", rule.Severity.String())
+			if rule.Message != "" {
+				notes = append(notes, rule.Message)
+			}
+		}
+
+		fmt.Fprintf(table, "%s\t%s\n", checker.Name, strings.Join(notes, ", "))
+	}
+
+	_ = table.Flush()
+
+	// The padding of the last column is trailing whitespace
+	lines := strings.Split(strings.TrimRight(list.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
 	}
 
+	return strings.Join(lines, "\n")
+}
+
+// loggers configures the standard logger and returns a logger for debug messages.
+func loggers(verbose, quiet bool) *log.Logger {
 	// Create a logger for debug messages
 	verboseLog := log.New(os.Stdout, "", 0)
-	if !*verbose {
+	if !verbose {
 		// Disable debug messages if the verbose flag is not set,
 		verboseLog.SetOutput(io.Discard)
 	}
 
 	// Disable the logger if the quiet flag is set
-	if *quiet {
+	if quiet {
 		log.SetOutput(io.Discard)
 		verboseLog.SetOutput(io.Discard)
 	}
 
-	// Split the exclude patterns into a slice
-	excludes := strings.Split(*exclude, ",")
+	return verboseLog
+}
+
+// splitExcludes splits the comma separated exclude patterns into a slice.
+func splitExcludes(exclude string) []string {
+	excludes := strings.Split(exclude, ",")
 
 	for i, exclude := range excludes {
 		// Remove any leading and trailing whitespace
@@ -107,16 +266,18 @@ func (w *Wslint) Parse() {
 		excludes[i] = exclude
 	}
 
-	w.Options = Options{
-		Exclude:         excludes,
-		NumberOfWorkers: *parallel,
-		Fix:             *fix,
-		Logger:          verboseLog,
-		Patterns:        flag.Args(),
-		Hidden:          *hidden,
-		Quiet:           *quiet,
-		Verbose:         *verbose,
-		Experimental:    *experimental,
-		Interactive:     *interactive,
+	return excludes
+}
+
+// splitLanguages splits the comma separated languages into a slice, ignoring empty entries.
+func splitLanguages(languages string) []string {
+	split := []string{}
+
+	for _, language := range strings.Split(languages, ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			split = append(split, language)
+		}
 	}
+
+	return split
 }
@@ -0,0 +1,105 @@
+package wslint
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"runtime"
+
+	"github.com/idelchi/wslint/internal/commitmsg"
+	"github.com/idelchi/wslint/internal/linter"
+	api "github.com/idelchi/wslint/pkg/wslint"
+)
+
+// ErrCommitMsgUsage is returned when the commit-msg subcommand is invoked incorrectly.
+var ErrCommitMsgUsage = errors.New("invalid commit-msg invocation")
+
+// commitMsgCheckers are the checkers applied to commit messages, selected explicitly so that the (experimental)
+// stutter checker is always applied.
+//
+//nolint:gochecknoglobals // Constant list.
+var commitMsgCheckers = []string{"stutter", "whitespace", "blanks"}
+
+// CommitMsg runs the commit-msg subcommand with the given arguments (following "commit-msg") and returns the
+// exit code. It lints (and with --fix, fixes) the message in a commit message file, as passed to the
+// commit-msg hook. When fixing, it returns ExitClean if all issues were fixed, so that the commit proceeds.
+func (w *Wslint) CommitMsg(args []string) int {
+	// No time stamp in the log output
+	log.SetFlags(0)
+
+	if err := w.ParseCommitMsg(args); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			log.Printf("Error: %v", err)
+		}
+
+		return ExitUsage
+	}
+
+	cfg := w.config()
+	cfg.Checkers = commitMsgCheckers
+	cfg.StutterExceptions = cfg.StutterExceptions.For(w.Options.Patterns[0])
+
+	checkers, err := api.NewCheckers(cfg)
+	if err != nil {
+		log.Printf("Error: %v", err)
+
+		return ExitUsage
+	}
+
+	lint := linter.New(w.Options.Patterns[0], commitmsg.Wrap(checkers))
+	lint.Context = w.Options.Context
+	lint.MaxIssues = maxIssues
+
+	w.Files = []linter.Linter{*lint}
+
+	return w.Process()
+}
+
+// ParseCommitMsg parses the arguments of the commit-msg subcommand into the options.
+func (w *Wslint) ParseCommitMsg(args []string) error {
+	set := flag.NewFlagSet("commit-msg", flag.ContinueOnError)
+	set.SetOutput(log.Writer())
+	set.Usage = func() {
+		log.Println("Usage: wslint commit-msg [flags] <file>    lint the message in a commit message file")
+		set.PrintDefaults()
+	}
+
+	// Flags for the commit-msg subcommand
+	var (
+		fix     = set.Bool("fix", false, "fix the commit message in-place")
+		context = set.Int("context", 0, "number of lines shown around each offending line")
+		diff    = set.Bool("diff", false, "show the unified diff of the fixes")
+		verbose = set.Bool("d", false, "debug output")
+		quiet   = set.Bool("q", false, "suppress messages")
+	)
+
+	if err := set.Parse(args); err != nil {
+		return err //nolint:wrapcheck // The error is printed by the flag set.
+	}
+
+	switch {
+	case set.NArg() != 1:
+		set.Usage()
+
+		return fmt.Errorf("%w: need to provide exactly one commit message file", ErrCommitMsgUsage)
+	case *context < 0:
+		return fmt.Errorf("%w: number of context lines must not be negative", ErrCommitMsgUsage)
+	}
+
+	w.Options = Options{
+		NumberOfWorkers: runtime.NumCPU(),
+		Fix:             *fix,
+		Logger:          loggers(*verbose, *quiet),
+		Patterns:        set.Args(),
+		Quiet:           *quiet,
+		Verbose:         *verbose,
+		// The fixes are written to the message file, so the commit can proceed.
+		ExitZeroOnFix:    *fix,
+		Context:          *context,
+		Diff:             *diff,
+		StutterLanguages: splitLanguages(defaultStutterLanguages),
+	}
+
+	return w.Options.load("", "", "")
+}
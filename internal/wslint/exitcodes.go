@@ -0,0 +1,38 @@
+package wslint
+
+import (
+	"errors"
+
+	"github.com/idelchi/wslint/internal/git"
+)
+
+// Exit codes returned by wslint.
+// They allow CI scripts to distinguish between a misconfigured invocation and files that need attention.
+const (
+	// ExitClean is returned when no issues were found.
+	ExitClean = 0
+	// ExitIssues is returned when issues were found and not (all) fixed.
+	ExitIssues = 1
+	// ExitUsage is returned on usage or configuration errors.
+	ExitUsage = 2
+	// ExitFixed is returned when issues were found and all of them were fixed.
+	ExitFixed = 3
+	// ExitNoFiles is returned when no files matched the patterns and `--fail-on-no-files` is set.
+	ExitNoFiles = 4
+	// ExitIO is returned when one or more files could not be read or written.
+	ExitIO = 5
+	// ExitVerify is returned when `--verify` finds that the fixes of one or more checkers are not idempotent,
+	// or when the fixes do not converge.
+	ExitVerify = 6
+)
+
+// MatchExitCode returns the exit code for an error of Match: ExitIO if the files could not be read through git
+// (e.g. a missing git command or an unreadable object database), or else ExitUsage (e.g. an invalid pattern,
+// an unknown revision or a directory outside of a git repository).
+func MatchExitCode(err error) int {
+	if errors.Is(err, git.ErrGit) {
+		return ExitIO
+	}
+
+	return ExitUsage
+}
@@ -0,0 +1,132 @@
+package wslint
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/idelchi/wslint/internal/git"
+	"github.com/idelchi/wslint/internal/hook"
+)
+
+// ErrHookUsage is returned when the hook subcommand is invoked incorrectly.
+var ErrHookUsage = errors.New("invalid hook invocation")
+
+// Hook runs the hook subcommand with the given arguments (following "hook") and returns the exit code:
+//   - "install" installs wslint as the pre-commit hook of the git repository containing the working directory,
+//     running "hook run" with the remaining arguments.
+//   - "run" lints (and with --fix, fixes) the staged content, as done by the installed hook.
+func (w *Wslint) Hook(args []string) int {
+	// No time stamp in the log output
+	log.SetFlags(0)
+
+	if err := w.ParseHook(args); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			log.Printf("Error: %v", err)
+		}
+
+		return ExitUsage
+	}
+
+	if args[0] == "install" {
+		return w.install(args[1:])
+	}
+
+	return w.ProcessStaged()
+}
+
+// ParseHook parses the arguments of the hook subcommand into the options.
+func (w *Wslint) ParseHook(args []string) error {
+	set := flag.NewFlagSet("hook", flag.ContinueOnError)
+	set.SetOutput(log.Writer())
+	set.Usage = func() {
+		log.Println("Usage: wslint hook install [flags]    install wslint as the pre-commit hook, running 'hook run [flags]'")
+		log.Println("       wslint hook run [flags]        lint the staged content")
+		set.PrintDefaults()
+	}
+
+	// Flags for the hook subcommands
+	var (
+		fix          = set.Bool("fix", false, "fix the staged content and stage the fixes")
+		exclude      = set.String("e", "", "exclude pattern, comma separated")
+		hidden       = set.Bool("a", false, "include hidden files & folders")
+		experimental = set.Bool("x", false, "enable experimental features")
+		context      = set.Int("context", 0, "number of lines shown around each offending line")
+		verbose      = set.Bool("d", false, "debug output")
+		quiet        = set.Bool("q", false, "suppress messages")
+	)
+
+	if len(args) == 0 || (args[0] != "install" && args[0] != "run") {
+		set.Usage()
+
+		return fmt.Errorf("%w: need to provide a subcommand, one of 'install' or 'run'", ErrHookUsage)
+	}
+
+	if err := set.Parse(args[1:]); err != nil {
+		return err //nolint:wrapcheck // The error is printed by the flag set.
+	}
+
+	switch {
+	case set.NArg() > 0:
+		set.Usage()
+
+		return fmt.Errorf("%w: unexpected arguments %q", ErrHookUsage, set.Args())
+	case *context < 0:
+		return fmt.Errorf("%w: number of context lines must not be negative", ErrHookUsage)
+	}
+
+	w.Options = Options{
+		Exclude:          splitExcludes(*exclude),
+		Fix:              *fix,
+		Logger:           loggers(*verbose, *quiet),
+		Hidden:           *hidden,
+		Quiet:            *quiet,
+		Verbose:          *verbose,
+		Experimental:     *experimental,
+		Context:          *context,
+		StutterLanguages: splitLanguages(defaultStutterLanguages),
+	}
+
+	return w.Options.load("", "", "")
+}
+
+// install installs the pre-commit hook, running "hook run" with the flags.
+func (w *Wslint) install(flags []string) int {
+	repo, err := git.Open(".")
+	if err != nil {
+		log.Printf("Error: %v", err)
+
+		return ExitUsage
+	}
+
+	dir, err := repo.HooksDir()
+	if err != nil {
+		log.Printf("Error: %v", err)
+
+		return ExitUsage
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		log.Printf("Error: locating the wslint executable: %v", err)
+
+		return ExitIO
+	}
+
+	chained, err := hook.Install(dir, executable, append([]string{"hook", "run"}, flags...)...)
+	if err != nil {
+		log.Printf("Error: %v", err)
+
+		return ExitIO
+	}
+
+	if chained {
+		log.Printf("The existing pre-commit hook is kept as %q and run before wslint", hook.Chained)
+	}
+
+	log.Printf("Installed the pre-commit hook in %q", dir)
+
+	return ExitClean
+}
@@ -0,0 +1,138 @@
+package wslint
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/idelchi/wslint/internal/git"
+	"github.com/idelchi/wslint/internal/worker"
+	"github.com/idelchi/wslint/pkg/gitattributes"
+	"github.com/idelchi/wslint/pkg/matcher"
+)
+
+// matchRev returns the files in the tree of the commit named by the Rev option that match the patterns,
+// relative to the root of the repository, and reads their content from the object database when processed.
+// The patterns are matched against the paths relative to the root of the repository.
+// The attributes are read from the .gitattributes files of the commit: files marked as binary are skipped,
+// while files marked as text are linted regardless of their content.
+func (w *Wslint) matchRev() ([]match, error) {
+	logger := w.Options.Logger
+
+	repo, err := git.Open(".")
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The error is self-explanatory.
+	}
+
+	entries, err := repo.Tree(w.Options.Rev)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The error is self-explanatory.
+	}
+
+	hashes := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		hashes[entry.Path] = entry.Hash
+	}
+
+	attributes := gitattributes.New(func(name string) ([]byte, error) {
+		hash, ok := hashes[name]
+		if !ok {
+			return nil, fs.ErrNotExist
+		}
+
+		return repo.ReadBlob(hash) //nolint:wrapcheck // The error is self-explanatory.
+	}, gitFile(repo, "info/attributes"), gitattributes.Global())
+
+	excludes := matcher.New(w.Options.Hidden, w.Options.Exclude, logger).Exclude
+
+	var files []match
+
+	text := make(map[string]bool)
+
+	for _, entry := range entries {
+		pattern, err := matchPatterns(entry.Path, w.Options.Patterns)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case pattern == "":
+			continue
+		case !entry.Regular():
+			logger.Printf("<skipped> %q <not a regular file>", entry.Path)
+
+			continue
+		}
+
+		name, state := filepath.FromSlash(entry.Path), attributes.Lookup(entry.Path)
+
+		switch {
+		// Files explicitly included (i.e no glob pattern is used) are included immediately, without inspection.
+		case matcher.IsExplicitlyIncluded(pattern):
+			logger.Printf("<exception> %q <explicitly included>", entry.Path)
+
+			text[name] = true
+		case matcher.IsExcluded(entry.Path, excludes) != "":
+			logger.Printf("<skipped> %q <matches exclude pattern> %q", entry.Path, matcher.IsExcluded(entry.Path, excludes))
+
+			continue
+		case state.Binary():
+			logger.Printf("<skipped> %q <binary according to .gitattributes>", entry.Path)
+
+			continue
+		default:
+			text[name] = state.Text()
+		}
+
+		files = append(files, match{name: name, attributes: state})
+	}
+
+	w.source = func(name string) ([]byte, error) {
+		content, err := repo.ReadBlob(hashes[filepath.ToSlash(name)])
+		if err != nil {
+			return nil, err //nolint:wrapcheck // The error is self-explanatory.
+		}
+
+		if !text[name] && matcher.IsBinaryContent(content) {
+			logger.Printf("<skipped> %q <detected as binary>", name)
+
+			return nil, worker.ErrSkipped
+		}
+
+		return content, nil
+	}
+
+	return files, nil
+}
+
+// gitFile returns the content of the file with the given name within the git directory, or nil if there is none.
+func gitFile(repo git.Repo, name string) []byte {
+	path, err := repo.GitPath(name)
+	if err != nil {
+		return nil
+	}
+
+	content, _ := os.ReadFile(path)
+
+	return content
+}
+
+// matchPatterns returns the first pattern the path matches, or an empty string if it matches none.
+func matchPatterns(path string, patterns []string) (string, error) {
+	for _, pattern := range patterns {
+		matched, err := doublestar.Match(strings.TrimPrefix(filepath.ToSlash(pattern), "./"), path)
+		if err != nil {
+			return "", fmt.Errorf("matching pattern %q: %w", pattern, err)
+		}
+
+		if matched {
+			return pattern, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,184 @@
+package wslint
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/internal/config"
+	"github.com/idelchi/wslint/internal/linter"
+	"github.com/idelchi/wslint/pkg/stuttering"
+	"github.com/idelchi/wslint/pkg/typos"
+	api "github.com/idelchi/wslint/pkg/wslint"
+)
+
+const (
+	// legacyStutterExceptions is the stutter exceptions file read if none is configured and it exists.
+	legacyStutterExceptions = "settings/stutters"
+	// legacyTyposWords is the list of allowed words read if none is configured and it exists.
+	legacyTyposWords = "settings/project-words"
+)
+
+// load reads the configuration file, with its rules and headers, and the stutter exceptions and allowed words.
+// The latter are read from the files given by the paths, or else by the configuration file, or else from
+// settings/stutters and settings/project-words if they exist.
+// An empty configuration path reads the default configuration file, if it exists.
+func (o *Options) load(configuration, exceptions, words string) error {
+	cfg, err := config.Load(cmp.Or(configuration, config.Default), configuration == "")
+	if err != nil {
+		return err //nolint:wrapcheck // The error is descriptive enough.
+	}
+
+	err = parseSetting(cmp.Or(exceptions, cfg.StutterExceptions), legacyStutterExceptions, func(r io.Reader) (err error) {
+		o.StutterExceptions, err = stuttering.ParseExceptions(r)
+
+		return err //nolint:wrapcheck // The error is wrapped by parseSetting.
+	})
+	if err != nil {
+		return fmt.Errorf("reading stutter exceptions: %w", err)
+	}
+
+	err = parseSetting(cmp.Or(words, cfg.TyposWords), legacyTyposWords, func(r io.Reader) (err error) {
+		o.TyposWords, err = typos.ParseWords(r)
+
+		return err //nolint:wrapcheck // The error is wrapped by parseSetting.
+	})
+	if err != nil {
+		return fmt.Errorf("reading allowed words: %w", err)
+	}
+
+	if err := o.compile(cfg.AllRules()); err != nil {
+		return err
+	}
+
+	return o.headers(cfg.Headers)
+}
+
+// compile compiles the rules of the configuration. It returns config.ErrInvalid for rules without an id or
+// a regular expression, with an id already in use, or with invalid regular expressions, glob patterns or severities.
+func (o *Options) compile(rules []config.Rule) error {
+	o.Rules = nil
+
+	used := make(map[string]bool)
+	for _, name := range api.Registered() {
+		used[name] = true
+	}
+
+	for _, rule := range rules {
+		if rule.ID == "" || rule.Regex == "" {
+			return fmt.Errorf("%w: rules need an id and a regex", config.ErrInvalid)
+		}
+
+		if used[rule.ID] {
+			return fmt.Errorf("%w: rule %q: id already in use", config.ErrInvalid, rule.ID)
+		}
+
+		used[rule.ID] = true
+
+		pattern, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return fmt.Errorf("%w: rule %q: %w", config.ErrInvalid, rule.ID, err)
+		}
+
+		for _, glob := range slices.Concat(rule.Include, rule.Exclude) {
+			if !doublestar.ValidatePattern(glob) {
+				return fmt.Errorf("%w: rule %q: %w: %q", config.ErrInvalid, rule.ID, doublestar.ErrBadPattern, glob)
+			}
+		}
+
+		severity, err := linter.ParseSeverity(rule.Severity)
+		if err != nil {
+			return fmt.Errorf("%w: rule %q: %w", config.ErrInvalid, rule.ID, err)
+		}
+
+		o.Rules = append(o.Rules, api.Rule{
+			Name:        rule.ID,
+			Message:     rule.Message,
+			Pattern:     pattern,
+			Include:     trimPrefixes(rule.Include),
+			Exclude:     trimPrefixes(rule.Exclude),
+			Severity:    severity,
+			Replacement: rule.Replacement,
+		})
+	}
+
+	return nil
+}
+
+// headers validates the headers of the configuration. It returns config.ErrInvalid for headers without a
+// template, with a negative number of lines, or with invalid glob patterns or placeholders.
+func (o *Options) headers(headers []config.Header) error {
+	o.Headers = nil
+
+	for i, header := range headers {
+		if strings.TrimSpace(header.Template) == "" {
+			return fmt.Errorf("%w: header %d: headers need a template", config.ErrInvalid, i+1)
+		}
+
+		if header.Lines < 0 {
+			return fmt.Errorf("%w: header %d: negative number of lines", config.ErrInvalid, i+1)
+		}
+
+		for _, glob := range slices.Concat(header.Include, header.Exclude) {
+			if !doublestar.ValidatePattern(glob) {
+				return fmt.Errorf("%w: header %d: %w: %q", config.ErrInvalid, i+1, doublestar.ErrBadPattern, glob)
+			}
+		}
+
+		if err := (checkers.Header{Template: header.Template}).Validate(); err != nil {
+			return fmt.Errorf("%w: header %d: %w", config.ErrInvalid, i+1, err)
+		}
+
+		o.Headers = append(o.Headers, api.Header{
+			Template: header.Template,
+			Comment:  header.Comment,
+			Start:    header.Start,
+			End:      header.End,
+			Within:   header.Lines,
+			Include:  trimPrefixes(header.Include),
+			Exclude:  trimPrefixes(header.Exclude),
+		})
+	}
+
+	return nil
+}
+
+// trimPrefixes removes "./" from the beginning of the glob patterns.
+func trimPrefixes(patterns []string) []string {
+	trimmed := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		trimmed = append(trimmed, strings.TrimPrefix(pattern, "./"))
+	}
+
+	return trimmed
+}
+
+// parseSetting parses the file at path with parse, or else the fallback file if path is empty and it exists.
+func parseSetting(path, fallback string, parse func(io.Reader) error) error {
+	optional := path == ""
+	path = cmp.Or(path, fallback)
+
+	file, err := os.Open(path)
+	if err != nil {
+		if optional && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err //nolint:wrapcheck // The error is wrapped by the caller.
+	}
+	defer file.Close()
+
+	if err := parse(file); err != nil {
+		return fmt.Errorf("%q: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package wslint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/config"
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+// TestOptions_compile tests the validation and compilation of the rules of the configuration.
+func TestOptions_compile(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name  string        // Name of the test case (for logging)
+		rules []config.Rule // Rules of the configuration
+		err   bool          // Whether an error is expected
+	}{
+		{
+			name: "valid rules",
+			rules: []config.Rule{
+				{ID: "no-console-log", Regex: `console\.log\(`, Include: []string{"./src/**/*.js"}, Severity: "Warning"},
+				{ID: "fixme-now", Regex: "FIXME-NOW"},
+			},
+		},
+		{name: "missing id", rules: []config.Rule{{Regex: "x"}}, err: true},
+		{name: "missing regex", rules: []config.Rule{{ID: "x"}}, err: true},
+		{name: "id of a checker", rules: []config.Rule{{ID: "blanks", Regex: "x"}}, err: true},
+		{name: "duplicate id", rules: []config.Rule{{ID: "x", Regex: "x"}, {ID: "x", Regex: "y"}}, err: true},
+		{name: "invalid regex", rules: []config.Rule{{ID: "x", Regex: "("}}, err: true},
+		{name: "invalid glob", rules: []config.Rule{{ID: "x", Regex: "x", Exclude: []string{"[a"}}}, err: true},
+		{name: "invalid severity", rules: []config.Rule{{ID: "x", Regex: "x", Severity: "fatal"}}, err: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var options Options
+
+			err := options.compile(tc.rules)
+			if tc.err {
+				require.ErrorIs(t, err, config.ErrInvalid)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, options.Rules, len(tc.rules))
+			require.Equal(t, linter.Warning, options.Rules[0].Severity)
+			require.Equal(t, []string{"src/**/*.js"}, options.Rules[0].Include)
+			require.True(t, options.Rules[0].Applies("./src/app/main.js"))
+			require.False(t, options.Rules[0].Applies("main.js"))
+		})
+	}
+}
+
+// TestOptions_headers tests the validation of the headers of the configuration.
+func TestOptions_headers(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string          // Name of the test case (for logging)
+		headers []config.Header // Headers of the configuration
+		err     bool            // Whether an error is expected
+	}{
+		{
+			name: "valid headers",
+			headers: []config.Header{
+				{Template: "Copyright {{year}} Acme", Comment: "// ", Lines: 5, Include: []string{"./**/*.go"}},
+				{Template: "SPDX-License-Identifier: MIT", Start: "<!--", End: "-->"},
+			},
+		},
+		{name: "missing template", headers: []config.Header{{Comment: "# ", Template: "\n"}}, err: true},
+		{name: "negative lines", headers: []config.Header{{Template: "x", Lines: -1}}, err: true},
+		{name: "invalid glob", headers: []config.Header{{Template: "x", Include: []string{"[a"}}}, err: true},
+		{name: "unknown placeholder", headers: []config.Header{{Template: "Copyright {{owner}}"}}, err: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var options Options
+
+			err := options.headers(tc.headers)
+			if tc.err {
+				require.ErrorIs(t, err, config.ErrInvalid)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, options.Headers, len(tc.headers))
+			require.Equal(t, 5, options.Headers[0].Within)
+			require.True(t, options.Headers[0].Applies("./cmd/main.go"))
+			require.False(t, options.Headers[0].Applies("README.md"))
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package wslint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/idelchi/wslint/internal/git"
+	"github.com/idelchi/wslint/internal/linter"
+	"github.com/idelchi/wslint/internal/writer"
+	"github.com/idelchi/wslint/pkg/gitattributes"
+	"github.com/idelchi/wslint/pkg/matcher"
+)
+
+// ProcessStaged lints the staged content of the git repository containing the working directory, as read
+// from the index, prints out the results and returns the exit code.
+// With the Fix option, the fixed content is written to the index, and merged into the working tree,
+// so that unstaged changes are preserved. Files whose unstaged changes conflict with the fixes are only
+// fixed in the index.
+func (w *Wslint) ProcessStaged() int {
+	repo, err := git.Open(".")
+	if err != nil {
+		log.Printf("Error: %v", err)
+
+		return ExitUsage
+	}
+
+	entries, err := repo.Staged()
+	if err != nil {
+		log.Printf("Error: %v", err)
+
+		return ExitIO
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Printf("Error: resolving the working directory: %v", err)
+
+		return ExitIO
+	}
+
+	excludes := matcher.New(w.Options.Hidden, w.Options.Exclude, w.Options.Logger).Exclude
+
+	// The attributes are read from the .gitattributes files of the working tree.
+	root, gitDir, _ := gitattributes.Repository(repo.Root)
+	attributes := gitattributes.Load(root, gitDir)
+	pipelines := make(pipelines)
+
+	var issues, unfixed, failed, unverified bool
+
+	for _, entry := range entries {
+		state := attributes.Lookup(entry.Path)
+
+		switch pattern := matcher.IsExcluded(entry.Path, excludes); {
+		case !entry.Regular():
+			w.Options.Logger.Printf("<skipped> %q <not a regular file>", entry.Path)
+
+			continue
+		case pattern != "":
+			w.Options.Logger.Printf("<skipped> %q <matches exclude pattern> %q", entry.Path, pattern)
+
+			continue
+		case state.Binary():
+			w.Options.Logger.Printf("<skipped> %q <binary according to .gitattributes>", entry.Path)
+
+			continue
+		}
+
+		checkers, err := w.checkers(pipelines, wd, entry.Path, state)
+		if err != nil {
+			log.Printf("Error: %v", err)
+
+			return ExitUsage
+		}
+
+		if len(checkers) == 0 {
+			w.Options.Logger.Printf("<skipped> %q <no checkers enabled by the whitespace attribute>", entry.Path)
+
+			continue
+		}
+
+		lint := linter.New(relative(repo, wd, entry.Path), slices.Clone(checkers))
+		lint.Context = w.Options.Context
+		lint.MaxIssues = maxIssues
+
+		lint.Error = w.staged(repo, entry, lint, state.Text())
+
+		if ok := lint.Summary(); ok {
+			continue
+		}
+
+		switch {
+		case errors.Is(lint.Error, linter.ErrNotConverged):
+			unverified = true
+		case lint.HasError():
+			failed = true
+		case lint.Fixed && lint.Fixable():
+			issues = true
+		default:
+			issues = true
+			unfixed = true
+		}
+	}
+
+	// The fixes are staged, so the commit can proceed.
+	w.Options.ExitZeroOnFix = true
+
+	return w.exitCode(issues, unfixed, failed, unverified)
+}
+
+// staged lints a single staged file and, with the Fix option, stages the fixed content and merges
+// it into the working tree.
+// Content is not inspected to detect binary files if marked as text.
+func (w *Wslint) staged(repo git.Repo, entry git.Entry, lint *linter.Linter, text bool) error {
+	content, err := repo.ReadBlob(entry.Hash)
+	if err != nil {
+		return err //nolint:wrapcheck // The error is self-explanatory.
+	}
+
+	if !text && matcher.IsBinaryContent(content) {
+		w.Options.Logger.Printf("<skipped> %q <detected as binary>", entry.Path)
+
+		return nil
+	}
+
+	w.Options.Logger.Printf("<processing> %q", entry.Path)
+
+	src := bytes.Split(content, []byte("\n"))
+	res, converged := lint.Format(src)
+
+	if !w.Options.Fix || slices.EqualFunc(src, res, bytes.Equal) {
+		return nil
+	}
+
+	// Content whose fixes do not converge is not staged.
+	if converged != nil {
+		return converged //nolint:wrapcheck // The error is self-explanatory.
+	}
+
+	fixed := bytes.Join(res, []byte("\n"))
+
+	if entry.Hash, err = repo.WriteBlob(fixed); err != nil {
+		return err //nolint:wrapcheck // The error is self-explanatory.
+	}
+
+	if err := repo.Stage(entry); err != nil {
+		return err //nolint:wrapcheck // The error is self-explanatory.
+	}
+
+	lint.Fixed = true
+
+	return w.unstaged(repo, entry.Path, content, fixed)
+}
+
+// unstaged merges the fixes of the staged content into the working tree, preserving the unstaged changes.
+// The working tree is left untouched if the file was removed or the unstaged changes conflict with the fixes.
+func (w *Wslint) unstaged(repo git.Repo, path string, staged, fixed []byte) error {
+	name := filepath.Join(repo.Root, filepath.FromSlash(path))
+
+	current, snapshot, err := writer.Read(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err //nolint:wrapcheck // The error is self-explanatory.
+	}
+
+	merged := fixed
+
+	if !bytes.Equal(current, staged) {
+		var conflicts bool
+
+		if merged, conflicts, err = repo.Merge(current, staged, fixed); err != nil {
+			return fmt.Errorf("merging fixes into the working tree: %w", err)
+		}
+
+		if conflicts {
+			log.Printf("Warning: %q fixed in the index only: the unstaged changes conflict with the fixes", path)
+
+			return nil
+		}
+	}
+
+	return writer.Writer{HardLinks: w.Options.HardLinks}.WriteFile(name, merged, snapshot) //nolint:wrapcheck // The error is self-explanatory.
+}
+
+// relative returns the path of a file in the repository, relative to the working directory wd if possible.
+func relative(repo git.Repo, wd, path string) string {
+	name := filepath.Join(repo.Root, filepath.FromSlash(path))
+
+	if rel, err := filepath.Rel(wd, name); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+
+	return filepath.FromSlash(path)
+}
@@ -2,78 +2,215 @@
 package wslint
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
-	"github.com/idelchi/wslint/internal/checkers"
 	"github.com/idelchi/wslint/internal/linter"
 	"github.com/idelchi/wslint/internal/worker"
+	"github.com/idelchi/wslint/internal/writer"
+	"github.com/idelchi/wslint/pkg/gitattributes"
 	"github.com/idelchi/wslint/pkg/matcher"
+	"github.com/idelchi/wslint/pkg/prose"
+	api "github.com/idelchi/wslint/pkg/wslint"
 )
 
+// maxIssues is the maximum number of issues reported per checker and file.
+const maxIssues = 1000
+
 // Wslint acts as a wrapper for the main functionality.
 type Wslint struct {
 	Options Options
 	Files   []linter.Linter
 	Usage   func()
 	Version string
+	// source reads the content of the files, if they are not read from the file system.
+	source func(name string) ([]byte, error)
+}
+
+// config assembles the checker configuration from the options.
+func (w *Wslint) config() api.Config {
+	cfg := api.Config{
+		Experimental:      w.Options.Experimental,
+		StutterLength:     w.Options.StutterLength,
+		StutterLanguages:  w.Options.StutterLanguages,
+		StutterExceptions: w.Options.StutterExceptions,
+		TyposWords:        w.Options.TyposWords,
+		Rules:             w.Options.Rules,
+		Headers:           w.Options.Headers,
+	}
+
+	return cfg
+}
+
+// match is a file to lint, along with its git attributes.
+type match struct {
+	name       string
+	attributes gitattributes.State
 }
 
 // Match stores the files that match the patterns.
-func (w *Wslint) Match() {
+// It returns an error if any of the patterns is invalid or the checkers cannot be created.
+func (w *Wslint) Match() error {
 	verboseLog := w.Options.Logger
-	patterns := w.Options.Patterns
-	hidden := w.Options.Hidden
-	exclude := w.Options.Exclude
 
-	// Create a matcher
-	matcher := matcher.New(hidden, exclude, verboseLog)
+	var (
+		files []match
+		err   error
+	)
 
-	// Collect the files to inspect, ranging over the patterns
-	for _, arg := range patterns {
-		if err := matcher.Match(arg); err != nil {
-			log.Printf("Error: %v", err)
+	// Collect the files to inspect, from the tree of the commit if requested
+	if w.Options.Rev != "" {
+		files, err = w.matchRev()
+	} else {
+		files, err = w.matchFiles()
+	}
 
-			return
-		}
+	if err != nil {
+		return err
+	}
+
+	// The scopes of the stutter exceptions, rules and headers are relative to the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("resolving the working directory: %w", err)
 	}
 
+	pipelines := make(pipelines)
+
 	// Fill the slice with files
-	for _, file := range matcher.ListFiles() {
-		// Get the relative path to the execution directory
-		if fileRel, err := filepath.Rel(".", file); err == nil {
-			file = fileRel
+	for _, file := range files {
+		checkers, err := w.checkers(pipelines, wd, file.name, file.attributes)
+		if err != nil {
+			return err
 		}
 
-		// TODO(Idelchi) Set up a factory function for this
-		lint := linter.New(file)
-
-		if w.Options.Experimental {
-			stutter := checkers.Stutter{}
-			// Load file in config/stutters and read into a slice of strings
-			// Pass the slice to the checker
-			// TODO(Idelchi): The configuration file is hardcoded
-			configurationFile := "settings/stutters"
-			if _, err := os.Stat(configurationFile); !os.IsNotExist(err) {
-				if content, err := os.ReadFile(configurationFile); err == nil {
-					stutter.Exceptions = strings.Split(string(content), "\n")
-				}
-			}
-
-			lint.InsertChecker("stutter", stutter)
+		if len(checkers) == 0 {
+			verboseLog.Printf("<skipped> %q <no checkers enabled by the whitespace attribute>", file.name)
+
+			continue
 		}
 
+		lint := linter.New(file.name, slices.Clone(checkers))
+		lint.Context = w.Options.Context
+		lint.MaxIssues = maxIssues
+
 		// Append the linter to the slice
 		w.Files = append(w.Files, *lint)
 
-		verboseLog.Printf("<included> %q", file)
+		verboseLog.Printf("<included> %q", file.name)
 	}
 
 	if len(w.Files) == 0 {
 		log.Println("No files found")
 	}
+
+	return nil
+}
+
+// pipeline identifies the configuration of the checkers that differs between files.
+type pipeline struct {
+	rules  gitattributes.Whitespace
+	syntax prose.Syntax
+	// exceptions lists the indices of the stutter exceptions applying to the file.
+	exceptions string
+	// custom lists the indices of the user-defined rules applying to the file.
+	custom string
+	// headers lists the indices of the headers applying to the file.
+	headers string
+}
+
+// pipelines caches the checkers created for each set of whitespace rules, syntax, stutter exceptions,
+// user-defined rules and headers.
+type pipelines map[pipeline][]linter.NamedChecker
+
+// checkers returns the checkers for a file with the given name and git attributes, configured by its
+// whitespace rules, its syntax, and the stutter exceptions, user-defined rules and headers scoped to it,
+// whose scopes are relative to the working directory wd.
+// It returns an error if the checkers cannot be created.
+func (w *Wslint) checkers(
+	pipelines pipelines, wd, name string, attributes gitattributes.State,
+) ([]linter.NamedChecker, error) {
+	scoped := name
+	if rel, err := filepath.Rel(wd, name); err == nil {
+		scoped = rel
+	}
+
+	key := pipeline{
+		rules:      attributes.Whitespace(),
+		syntax:     prose.Detect(name),
+		exceptions: applying(w.Options.StutterExceptions, scoped),
+		custom:     applying(w.Options.Rules, scoped),
+		headers:    applying(w.Options.Headers, scoped),
+	}
+
+	if checkers, ok := pipelines[key]; ok {
+		return checkers, nil
+	}
+
+	cfg := w.config()
+	cfg.Whitespace = &key.rules
+	cfg.Syntax = key.syntax
+	cfg.StutterExceptions = cfg.StutterExceptions.For(scoped)
+	cfg.Rules = cfg.Rules.For(scoped)
+	cfg.Headers = cfg.Headers.For(scoped)
+
+	// Create the checkers from the registry shared with the embeddable API
+	checkers, err := api.NewCheckers(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines[key] = checkers
+
+	return checkers, nil
+}
+
+// applying returns the indices of the items applying to the path, separated by commas.
+func applying[T interface{ Applies(path string) bool }](items []T, path string) string {
+	var applied []string
+
+	for i, item := range items {
+		if item.Applies(path) {
+			applied = append(applied, strconv.Itoa(i))
+		}
+	}
+
+	return strings.Join(applied, ",")
+}
+
+// matchFiles returns the files in the file system that match the patterns, relative to the execution
+// directory where possible.
+func (w *Wslint) matchFiles() ([]match, error) {
+	// Create a matcher
+	matcher := matcher.New(w.Options.Hidden, w.Options.Exclude, w.Options.Logger)
+
+	// Collect the files to inspect, ranging over the patterns
+	for _, arg := range w.Options.Patterns {
+		if err := matcher.Match(arg); err != nil {
+			return nil, err
+		}
+	}
+
+	files := make([]match, 0, len(matcher.ListFiles()))
+
+	for _, file := range matcher.ListFiles() {
+		attributes := matcher.Attributes(file)
+
+		// Get the relative path to the execution directory
+		if fileRel, err := filepath.Rel(".", file); err == nil {
+			file = fileRel
+		}
+
+		files = append(files, match{name: file, attributes: attributes})
+	}
+
+	return files, nil
 }
 
 // Process processes the files, prints out the results and returns the exit code.
@@ -87,6 +224,11 @@ func (w *Wslint) Process() int {
 		NumberOfWorkers: w.Options.NumberOfWorkers,
 		NumberOfJobs:    numberOfFiles,
 		Fix:             w.Options.Fix,
+		Writer:          writer.Writer{HardLinks: w.Options.HardLinks},
+		Lock:            w.Options.Lock,
+		Verify:          w.Options.Verify,
+		Diff:            w.Options.Diff,
+		Source:          w.source,
 		Files:           w.Files,
 		Logger:          w.Options.Logger,
 	}
@@ -97,22 +239,91 @@ func (w *Wslint) Process() int {
 
 	workerPool.Start(jobs, results)
 
-	exitCode := 0
+	var issues, unfixed, failed, unverified bool
 
 	// Collect the results
-	for range w.Files {
-		result := <-results
+	w.collect(results, func(result linter.Linter) {
+		if ok := result.Summary(); ok {
+			return
+		}
 
-		if ok := result.Summary(); !ok {
-			exitCode = 1
+		switch {
+		case errors.Is(result.Error, linter.ErrNotIdempotent), errors.Is(result.Error, linter.ErrNotConverged):
+			unverified = true
+		case result.HasError():
+			failed = true
+		case result.Fixed && result.Fixable():
+			issues = true
+		default:
+			issues = true
+			unfixed = true
 		}
-	}
+	})
 
 	workerPool.Stats()
 
-	if exitCode == 0 {
-		log.Println("No issues found")
+	return w.exitCode(issues, unfixed, failed, unverified)
+}
+
+// collect receives the results, which arrive in the order of completion, and passes them on to report
+// in a deterministic order. By default, all results are collected first and reported sorted by path.
+// With the StreamOutput option, each result is reported as soon as all preceding files (in input order)
+// have been reported.
+func (w *Wslint) collect(results <-chan linter.Linter, report func(linter.Linter)) {
+	if !w.Options.StreamOutput {
+		collected := make([]linter.Linter, 0, len(w.Files))
+		for result := range results {
+			collected = append(collected, result)
+		}
+
+		slices.SortFunc(collected, func(a, b linter.Linter) int { return strings.Compare(a.Name, b.Name) })
+
+		for _, result := range collected {
+			report(result)
+		}
+
+		return
 	}
 
-	return exitCode
+	order := make(map[string]int, len(w.Files))
+	for i, file := range w.Files {
+		order[file.Name] = i
+	}
+
+	pending := make(map[int]linter.Linter)
+	next := 0
+
+	for result := range results {
+		pending[order[result.Name]] = result
+
+		for result, ok := pending[next]; ok; result, ok = pending[next] {
+			report(result)
+			delete(pending, next)
+			next++
+		}
+	}
+}
+
+// exitCode determines the exit code from the outcome of the processing.
+func (w *Wslint) exitCode(issues, unfixed, failed, unverified bool) int {
+	switch {
+	case failed:
+		return ExitIO
+	case unverified:
+		return ExitVerify
+	case unfixed:
+		return ExitIssues
+	case issues && w.Options.ExitZeroOnFix:
+		log.Println("All issues fixed")
+
+		return ExitClean
+	case issues:
+		log.Println("All issues fixed")
+
+		return ExitFixed
+	default:
+		log.Println("No issues found")
+
+		return ExitClean
+	}
 }
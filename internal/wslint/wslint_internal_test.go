@@ -0,0 +1,136 @@
+package wslint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/git"
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+// TestWslint_exitCode tests the mapping of the processing outcome to the exit code.
+func TestWslint_exitCode(t *testing.T) { //nolint:paralleltest // The standard logger is silenced globally.
+	log.SetOutput(io.Discard)
+
+	tcs := []struct {
+		name          string // Name of the test case (for logging)
+		issues        bool   // Whether issues were found
+		unfixed       bool   // Whether issues remain unfixed
+		failed        bool   // Whether a file could not be processed
+		unverified    bool   // Whether the fixes of a file could not be verified
+		exitZeroOnFix bool   // Whether the --exit-zero-on-fix flag is set
+		expected      int    // Expected exit code
+	}{
+		{
+			name:     "clean",
+			expected: ExitClean,
+		},
+		{
+			name:     "issues found",
+			issues:   true,
+			unfixed:  true,
+			expected: ExitIssues,
+		},
+		{
+			name:     "issues fixed",
+			issues:   true,
+			expected: ExitFixed,
+		},
+		{
+			name:          "issues fixed with exit zero on fix",
+			issues:        true,
+			exitZeroOnFix: true,
+			expected:      ExitClean,
+		},
+		{
+			name:          "unfixed issues with exit zero on fix",
+			issues:        true,
+			unfixed:       true,
+			exitZeroOnFix: true,
+			expected:      ExitIssues,
+		},
+		{
+			name:       "fixes not idempotent",
+			issues:     true,
+			unfixed:    true,
+			unverified: true,
+			expected:   ExitVerify,
+		},
+		{
+			name:     "I/O errors take precedence",
+			issues:   true,
+			unfixed:  true,
+			failed:   true,
+			expected: ExitIO,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			w := Wslint{Options: Options{ExitZeroOnFix: tc.exitZeroOnFix}}
+
+			require.Equal(t, tc.expected, w.exitCode(tc.issues, tc.unfixed, tc.failed, tc.unverified))
+		})
+	}
+}
+
+// TestMatchExitCode tests the mapping of the errors of Match to the exit code.
+func TestMatchExitCode(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ExitIO, MatchExitCode(fmt.Errorf("%w: git cat-file: exit status 128", git.ErrGit)))
+	require.Equal(t, ExitUsage, MatchExitCode(fmt.Errorf("%w: %q", git.ErrUnknownRevision, "v0")))
+	require.Equal(t, ExitUsage, MatchExitCode(fmt.Errorf("%w: %q", git.ErrNotRepository, ".")))
+	require.Equal(t, ExitUsage, MatchExitCode(errors.New("invalid pattern")))
+}
+
+// TestWslint_collect tests that the results are reported in a deterministic order,
+// regardless of the order of completion.
+func TestWslint_collect(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string   // Name of the test case (for logging)
+		stream   bool     // Whether to report in input order as soon as possible
+		expected []string // Expected order of the reported files
+	}{
+		{
+			name:     "sorted by path",
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:     "input order",
+			stream:   true,
+			expected: []string{"c", "a", "d", "b"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := Wslint{Options: Options{StreamOutput: tc.stream}}
+			for _, name := range []string{"c", "a", "d", "b"} {
+				w.Files = append(w.Files, linter.Linter{Name: name})
+			}
+
+			results := make(chan linter.Linter, len(w.Files))
+			for _, name := range []string{"b", "d", "a", "c"} {
+				results <- linter.Linter{Name: name}
+			}
+
+			close(results)
+
+			var reported []string
+
+			w.collect(results, func(result linter.Linter) { reported = append(reported, result.Name) })
+
+			require.Equal(t, tc.expected, reported)
+		})
+	}
+}
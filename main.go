@@ -4,6 +4,8 @@ Wslint formats files, removing trailing whitespaces and enforcing exactly one bl
 Usage:
 
 	wslint [flags] [path ...]
+	wslint hook install|run [flags]
+	wslint commit-msg [flags] <file>
 
 Paths can be specified as one or more glob patterns or simple file paths.
 
@@ -20,6 +22,44 @@ The flags are:
 	-d		Show debug output.
 	-q		Suppress messages.
 	-x		Enable experimental features.
+
+	--fail-on-no-files	Exit with code 4 if no files matched the patterns.
+	--exit-zero-on-fix	Exit with code 0 instead of 3 if all issues were fixed.
+	--hardlinks		Fix hard-linked files "inplace" (default) or "skip" them.
+	--lock			Take an advisory lock on the sidecar file <file>.lock while processing a file.
+	--stream-output		Report files as they are processed, in input order, instead of sorted by path.
+	--verify		Relint fixed content and fail if any issue remains.
+	--context N		Number of lines shown around each offending line (default 0).
+	--diff			Show the unified diff of the fixes (combine with -w to also write them).
+	--rev COMMIT		Lint the files in the tree of the commit, read from git, instead of the file system.
+	--stutter-length N	Maximum number of words of a repeated phrase reported as a stutter (default 3).
+	--stutter-languages	Languages of legitimate repetitions (en, de, fr, nl), separated by commas (default en).
+	--stutter-exceptions	File listing the repetitions not reported (default settings/stutters, if it exists).
+	--typos-words		File listing the words not reported as misspelled (default settings/project-words, if it exists).
+	--config FILE		Configuration file (default .wslint.yaml, if it exists), e.g. defining rules and headers.
+	--list-checkers		List the checkers, including the rules of the configuration, and exit.
+
+Within a git repository, the .gitattributes files are honoured: files marked as binary are skipped, files marked
+as text are linted regardless of their content, and the whitespace attribute selects the whitespace rules
+(blank-at-eol, blank-at-eof, trailing-space, space-before-tab and cr-at-eol) that are checked.
+
+The hook subcommand installs wslint as the git pre-commit hook ("hook install"), which lints the staged content
+("hook run"). It accepts the flags -e, -a, -x, -q, -d and --context, as well as --fix to fix and restage the
+staged content, while preserving unstaged changes.
+
+The commit-msg subcommand lints the message in a commit message file, ignoring comment lines and the scissors
+section, always applying the stutter checker. It accepts the flags -q, -d, --context and --diff, as well as --fix to
+fix the file in-place and exit with 0 if all issues were fixed, as needed for the commit-msg hook.
+
+The exit codes are:
+
+	0	No issues found.
+	1	Issues found (and not fixed).
+	2	Usage or configuration error.
+	3	Issues found and all of them fixed.
+	4	No files matched the patterns (only with --fail-on-no-files).
+	5	One or more files could not be read or written.
+	6	The fixes do not converge, or are not idempotent (with --verify).
 */
 package main
 
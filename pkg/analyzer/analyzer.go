@@ -0,0 +1,185 @@
+// Package analyzer provides a go/analysis Analyzer running the wslint checkers over the Go files of a package.
+//
+// It allows running the wslint checks in the same pass as other analyzers, for example through
+// a custom vet tool:
+//
+//	func main() {
+//		singlechecker.Main(analyzer.Analyzer)
+//	}
+//
+// or as a golangci-lint plugin. Each diagnostic carries a suggested fix.
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/idelchi/wslint/pkg/prose"
+	"github.com/idelchi/wslint/pkg/wslint"
+)
+
+// Analyzer runs the whitespace, blanks and stutter checkers over the Go files of a package.
+//
+//nolint:gochecknoglobals // Analyzers are global by convention.
+var Analyzer = New()
+
+// New creates a new Analyzer.
+// The checkers to run can be selected with the "checkers" flag, as a comma separated list.
+func New() *analysis.Analyzer {
+	runner := &runner{checkers: "whitespace,blanks,stutter"}
+
+	analyzer := &analysis.Analyzer{
+		Name: "wslint",
+		Doc:  "checks for trailing whitespace, trailing blank lines and stuttering words",
+		URL:  "https://github.com/idelchi/wslint",
+		Run:  runner.run,
+	}
+
+	analyzer.Flags.StringVar(&runner.checkers, "checkers", runner.checkers, "comma separated list of checkers to run")
+
+	return analyzer
+}
+
+// runner holds the configuration of an Analyzer.
+type runner struct {
+	checkers string
+}
+
+// run reports the issues found by the checkers as diagnostics, along with a suggested fix.
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	names := strings.Split(r.checkers, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+
+	checkers, err := wslint.NewCheckers(wslint.Config{Checkers: names, Syntax: prose.Go})
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The error is self-explanatory.
+	}
+
+	for _, file := range pass.Files {
+		tokenFile := pass.Fset.File(file.Pos())
+		if tokenFile == nil {
+			continue
+		}
+
+		content, err := os.ReadFile(tokenFile.Name())
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", tokenFile.Name(), err)
+		}
+
+		// The content on disk does not match the parsed file, for example with cgo or generated files.
+		if len(content) != tokenFile.Size() {
+			continue
+		}
+
+		report(pass, tokenFile, content, checkers)
+	}
+
+	return nil, nil //nolint:nilnil // The analyzer produces no result.
+}
+
+// report runs the checkers on the content of a file and reports the issues found as diagnostics.
+// All checkers check the original content, so that the positions of the diagnostics remain valid.
+// Issues with edits conflicting with the edits of an earlier checker are reported without a suggested fix,
+// as are issues whose position lies outside of the content, which are reported at the start of their line.
+func report(pass *analysis.Pass, file *token.File, content []byte, checkers []wslint.NamedChecker) {
+	lines := bytes.Split(content, []byte("\n"))
+
+	type found struct {
+		checker string
+		issue   wslint.Issue
+		// first is the index of the first edit of the issue among all edits.
+		first int
+	}
+
+	var (
+		issues []found
+		edits  []wslint.Edit
+	)
+
+	for _, checker := range checkers {
+		for _, issue := range checker.Checker.Check(lines) {
+			issues = append(issues, found{checker: checker.Name, issue: issue, first: len(edits)})
+			edits = append(edits, issue.Edits...)
+		}
+	}
+
+	_, rejected := wslint.Apply(content, edits)
+
+	// Conflicts are tracked by index, as equal edits of two issues may be both applied and rejected.
+	conflicts := make(map[int]bool, len(rejected))
+	for _, index := range rejected {
+		conflicts[index] = true
+	}
+
+	for _, found := range issues {
+		diagnostic := analysis.Diagnostic{
+			Category: found.checker,
+			Message:  found.checker + ": " + found.issue.Message,
+		}
+
+		pos, end, err := wslint.Edit{
+			Line:      found.issue.Line,
+			Column:    found.issue.Column,
+			EndLine:   found.issue.Line,
+			EndColumn: found.issue.EndColumn,
+		}.Offsets(lines)
+		if err != nil {
+			diagnostic.Pos = lineStart(file, found.issue.Line)
+			pass.Report(diagnostic)
+
+			continue
+		}
+
+		diagnostic.Pos, diagnostic.End = file.Pos(pos), file.Pos(end)
+
+		if fix, ok := suggest(file, lines, found.issue, found.first, conflicts); ok {
+			fix.Message = "Fix " + found.checker + " issue"
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+
+		pass.Report(diagnostic)
+	}
+}
+
+// lineStart returns the position of the start of the (1-based) line, or of the file if there is no such line.
+func lineStart(file *token.File, line int) token.Pos {
+	if line < 1 || line > file.LineCount() {
+		return file.Pos(0)
+	}
+
+	return file.LineStart(line)
+}
+
+// suggest converts the edits of an issue, the first of which is at the given index among all edits,
+// into a suggested fix. It returns false if the issue has no edits, or any of them conflicts.
+func suggest(
+	file *token.File, lines [][]byte, issue wslint.Issue, first int, conflicts map[int]bool,
+) (analysis.SuggestedFix, bool) {
+	var fix analysis.SuggestedFix
+
+	for i, edit := range issue.Edits {
+		if conflicts[first+i] {
+			return fix, false
+		}
+
+		start, end, err := edit.Offsets(lines)
+		if err != nil {
+			return fix, false
+		}
+
+		fix.TextEdits = append(fix.TextEdits, analysis.TextEdit{
+			Pos:     file.Pos(start),
+			End:     file.Pos(end),
+			NewText: []byte(edit.Text),
+		})
+	}
+
+	return fix, len(fix.TextEdits) > 0
+}
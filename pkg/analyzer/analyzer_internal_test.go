@@ -0,0 +1,55 @@
+package analyzer
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/idelchi/wslint/pkg/wslint"
+)
+
+// fixed reports the given issues, regardless of the lines.
+type fixed []wslint.Issue
+
+func (f fixed) Check([][]byte) []wslint.Issue {
+	return f
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("package example \n")
+	trailing := wslint.Edit{Line: 1, Column: 16, EndLine: 1, EndColumn: 17}
+
+	checkers := []wslint.NamedChecker{
+		{Name: "first", Checker: fixed{{Line: 1, Column: 16, EndColumn: 17, Message: "a", Edits: []wslint.Edit{trailing}}}},
+		{Name: "second", Checker: fixed{
+			{Line: 1, Column: 16, EndColumn: 17, Message: "b", Edits: []wslint.Edit{trailing}},
+			{Line: 5, Column: 1, EndColumn: 2, Message: "c"},
+		}},
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("example.go", -1, len(content))
+	file.SetLinesForContent(content)
+
+	var diagnostics []analysis.Diagnostic
+
+	pass := &analysis.Pass{Report: func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) }}
+	report(pass, file, content, checkers)
+
+	require.Len(t, diagnostics, 3)
+
+	// The edit equal to an applied edit is rejected, without rejecting the applied one.
+	require.Equal(t, "first: a", diagnostics[0].Message)
+	require.Len(t, diagnostics[0].SuggestedFixes, 1)
+	require.Equal(t, "second: b", diagnostics[1].Message)
+	require.Empty(t, diagnostics[1].SuggestedFixes)
+
+	// The issue outside of the content is reported at the start of the file, without a fix.
+	require.Equal(t, "second: c", diagnostics[2].Message)
+	require.Equal(t, file.Pos(0), diagnostics[2].Pos)
+	require.Empty(t, diagnostics[2].SuggestedFixes)
+}
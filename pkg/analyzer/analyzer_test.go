@@ -0,0 +1,143 @@
+package analyzer_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/idelchi/wslint/pkg/analyzer"
+)
+
+// run runs the analyzer on a single file with the given content and returns the diagnostics reported.
+func run(t *testing.T, content string) (*token.FileSet, []analysis.Diagnostic) {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "example.go")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, file, content, parser.ParseComments)
+	require.NoError(t, err)
+
+	var diagnostics []analysis.Diagnostic
+
+	pass := &analysis.Pass{
+		Analyzer: analyzer.Analyzer,
+		Fset:     fset,
+		Files:    []*ast.File{parsed},
+		Report:   func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) },
+	}
+
+	_, err = analyzer.Analyzer.Run(pass)
+	require.NoError(t, err)
+
+	return fset, diagnostics
+}
+
+// apply applies the suggested fixes of the diagnostics to the content, from the last to the first.
+func apply(t *testing.T, fset *token.FileSet, content string, diagnostics []analysis.Diagnostic) string {
+	t.Helper()
+
+	var edits []analysis.TextEdit
+
+	for _, diagnostic := range diagnostics {
+		for _, fix := range diagnostic.SuggestedFixes {
+			edits = append(edits, fix.TextEdits...)
+		}
+	}
+
+	slices.SortFunc(edits, func(a, b analysis.TextEdit) int { return int(b.Pos - a.Pos) })
+
+	for _, edit := range edits {
+		start := fset.Position(edit.Pos).Offset
+		end := fset.Position(edit.End).Offset
+		content = content[:start] + string(edit.NewText) + content[end:]
+	}
+
+	return content
+}
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string   // Name of the test case (for logging)
+		content  string   // Content of the Go file
+		messages []string // Messages expected to be reported
+		lines    []int    // Lines expected to be reported
+		fixed    string   // Content after applying the suggested fixes
+	}{
+		{
+			name:    "clean",
+			content: "package example\n",
+			fixed:   "package example\n",
+		},
+		{
+			name:     "trailing whitespace",
+			content:  "package example \t\n\nvar x = 1 \n",
+			messages: []string{"whitespace: has trailing whitespace", "whitespace: has trailing whitespace"},
+			lines:    []int{1, 3},
+			fixed:    "package example\n\nvar x = 1\n",
+		},
+		{
+			name:    "trailing blank lines",
+			content: "package example\n\n\n",
+			messages: []string{
+				"blanks: more than one blank line at the end of the file",
+				"blanks: more than one blank line at the end of the file",
+			},
+			lines: []int{2, 3},
+			fixed: "package example\n",
+		},
+		{
+			name:     "missing blank line",
+			content:  "package example",
+			messages: []string{"blanks: no blank lines at the end of the file"},
+			lines:    []int{1},
+			fixed:    "package example\n",
+		},
+		{
+			name:    "conflicting fixes",
+			content: "package example\n\n \n",
+			messages: []string{
+				"whitespace: has trailing whitespace",
+				"blanks: more than one blank line at the end of the file",
+				"blanks: more than one blank line at the end of the file",
+			},
+			lines: []int{3, 2, 3},
+			// The removal of the third line conflicts with the removal of its trailing whitespace.
+			fixed: "package example\n\n",
+		},
+		{
+			name:     "stutter",
+			content:  "// Package example is an an example.\npackage example\n",
+			messages: []string{"stutter: stutters (an an)"},
+			lines:    []int{1},
+			fixed:    "// Package example is an example.\npackage example\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset, diagnostics := run(t, tc.content)
+
+			require.Len(t, diagnostics, len(tc.messages))
+
+			for i, diagnostic := range diagnostics {
+				require.Equal(t, tc.messages[i], diagnostic.Message)
+				require.Equal(t, tc.lines[i], fset.Position(diagnostic.Pos).Line)
+			}
+
+			require.Equal(t, tc.fixed, apply(t, fset, tc.content, diagnostics))
+		})
+	}
+}
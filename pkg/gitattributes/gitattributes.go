@@ -0,0 +1,309 @@
+// Package gitattributes resolves the git attributes of the files in a repository, as assigned by the
+// .gitattributes files at all levels, without requiring git.
+//
+// The attributes are read, from lowest to highest precedence, from the global attributes file
+// ($XDG_CONFIG_HOME/git/attributes or ~/.config/git/attributes), the .gitattributes files from the root of
+// the repository down to the directory of the file, and the $GIT_DIR/info/attributes file. Within a file,
+// later lines take precedence over earlier ones. Macro attributes (including the built-in binary macro)
+// are expanded.
+package gitattributes
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Values of set and unset attributes. Attributes assigned a value have that value instead.
+const (
+	Set   = "set"
+	Unset = "unset"
+)
+
+// Name is the name of the attribute files in the working tree.
+const Name = ".gitattributes"
+
+// Reader reads the named file, given relative to the root of the repository and slash-separated.
+// It returns an error wrapping fs.ErrNotExist if the file does not exist.
+type Reader func(name string) ([]byte, error)
+
+// State holds the attributes specified for a path, by name: Set, Unset or the value assigned.
+// Unspecified attributes are absent.
+type State map[string]string
+
+// assignment assigns a value to an attribute. An empty value makes the attribute unspecified.
+type assignment struct {
+	name, value string
+}
+
+// rule assigns attributes to the paths matching a pattern, relative to the directory of its file.
+type rule struct {
+	// base is the directory of the file the rule was read from, relative to the root ("" for the root).
+	base string
+	// pattern matches the path relative to base if anchored, or the base name of the path otherwise.
+	pattern     string
+	anchored    bool
+	assignments []assignment
+}
+
+// Attributes resolves the attributes of the paths in a repository.
+type Attributes struct {
+	read   Reader
+	global []rule
+	info   []rule
+	macros map[string][]assignment
+
+	mu sync.Mutex
+	// dirs caches the rules of the .gitattributes file of each directory.
+	dirs map[string][]rule
+}
+
+// New returns the attributes of the repository whose .gitattributes files are read through the reader,
+// along with the content of its info attributes file and of the global attributes file (both may be nil).
+func New(read Reader, info, global []byte) *Attributes {
+	attributes := &Attributes{
+		read: read,
+		macros: map[string][]assignment{
+			"binary": {{"diff", Unset}, {"merge", Unset}, {"text", Unset}},
+		},
+		dirs: make(map[string][]rule),
+	}
+
+	attributes.global = attributes.parse("", global, true)
+	attributes.info = attributes.parse("", info, true)
+
+	return attributes
+}
+
+// Load returns the attributes of the working tree at root, with the git directory gitDir,
+// reading the .gitattributes files from disk.
+func Load(root, gitDir string) *Attributes {
+	read := func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	}
+
+	info, _ := os.ReadFile(filepath.Join(common(gitDir), "info", "attributes"))
+
+	return New(read, info, Global())
+}
+
+// Global returns the content of the global attributes file, or nil if there is none.
+func Global() []byte {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+
+		dir = filepath.Join(home, ".config")
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "git", "attributes"))
+
+	return content
+}
+
+// Repository returns the root of the working tree containing the directory, along with its git directory,
+// by looking for ".git" in the directory and its parents. It returns false if there is none.
+func Repository(dir string) (root, gitDir string, ok bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", false
+	}
+
+	for {
+		candidate := filepath.Join(dir, ".git")
+
+		if info, err := os.Stat(candidate); err == nil {
+			if info.IsDir() {
+				return dir, candidate, true
+			}
+
+			// Linked working trees and submodules refer to their git directory.
+			if content, err := os.ReadFile(candidate); err == nil {
+				if target, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:"); ok {
+					target = filepath.FromSlash(strings.TrimSpace(target))
+					if !filepath.IsAbs(target) {
+						target = filepath.Join(dir, target)
+					}
+
+					return dir, target, true
+				}
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+
+		dir = parent
+	}
+}
+
+// Lookup returns the attributes of the path, given relative to the root of the repository and slash-separated.
+func (a *Attributes) Lookup(name string) State {
+	state := make(State)
+
+	a.apply(state, a.global, name)
+
+	// From the root down to the directory of the path, each .gitattributes file taking precedence over its parents.
+	var dirs []string
+
+	for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+
+	dirs = append(dirs, "")
+	slices.Reverse(dirs)
+
+	for _, dir := range dirs {
+		a.apply(state, a.dir(dir), name)
+	}
+
+	a.apply(state, a.info, name)
+
+	return state
+}
+
+// dir returns the rules of the .gitattributes file in the directory, reading it on first use.
+func (a *Attributes) dir(dir string) []rule {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	rules, ok := a.dirs[dir]
+	if !ok {
+		content, _ := a.read(path.Join(dir, Name))
+		// Macros can only be defined at the root.
+		rules = a.parse(dir, content, dir == "")
+		a.dirs[dir] = rules
+	}
+
+	return rules
+}
+
+// apply applies the assignments of the rules matching the path to the state, in order.
+func (a *Attributes) apply(state State, rules []rule, name string) {
+	for _, rule := range rules {
+		if !rule.matches(name) {
+			continue
+		}
+
+		for _, assignment := range rule.assignments {
+			a.assign(state, assignment)
+		}
+	}
+}
+
+// assign applies a single assignment to the state, expanding set macros.
+func (a *Attributes) assign(state State, assignment assignment) {
+	if assignment.value == "" {
+		delete(state, assignment.name)
+
+		return
+	}
+
+	state[assignment.name] = assignment.value
+
+	if assignment.value == Set {
+		for _, expanded := range a.macros[assignment.name] {
+			a.assign(state, expanded)
+		}
+	}
+}
+
+// parse parses the content of an attributes file in the directory. Macro definitions are only
+// honoured if macros is set, and lines that cannot be parsed are ignored, as done by git.
+func (a *Attributes) parse(dir string, content []byte, macros bool) (rules []rule) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		pattern, assignments := fields[0], parseAssignments(fields[1:])
+
+		switch {
+		case strings.HasPrefix(pattern, "[attr]"):
+			if macros {
+				a.macros[strings.TrimPrefix(pattern, "[attr]")] = assignments
+			}
+		// Negative patterns are forbidden, and directory patterns never match files.
+		case strings.HasPrefix(pattern, "!"), strings.HasSuffix(pattern, "/"):
+		default:
+			rules = append(rules, rule{
+				base:        dir,
+				pattern:     strings.TrimPrefix(pattern, "/"),
+				anchored:    strings.Contains(pattern, "/"),
+				assignments: assignments,
+			})
+		}
+	}
+
+	return rules
+}
+
+// parseAssignments parses the attributes of a line: "name" sets, "-name" unsets, "!name" unspecifies
+// and "name=value" assigns a value.
+func parseAssignments(fields []string) []assignment {
+	assignments := make([]assignment, 0, len(fields))
+
+	for _, field := range fields {
+		switch {
+		case strings.HasPrefix(field, "-"):
+			assignments = append(assignments, assignment{strings.TrimPrefix(field, "-"), Unset})
+		case strings.HasPrefix(field, "!"):
+			assignments = append(assignments, assignment{name: strings.TrimPrefix(field, "!")})
+		case strings.Contains(field, "="):
+			name, value, _ := strings.Cut(field, "=")
+			assignments = append(assignments, assignment{name, value})
+		default:
+			assignments = append(assignments, assignment{field, Set})
+		}
+	}
+
+	return assignments
+}
+
+// matches returns true if the rule applies to the path, given relative to the root.
+func (r rule) matches(name string) bool {
+	if r.base != "" {
+		var ok bool
+		if name, ok = strings.CutPrefix(name, r.base+"/"); !ok {
+			return false
+		}
+	}
+
+	if !r.anchored {
+		name = path.Base(name)
+	}
+
+	matched, _ := doublestar.Match(r.pattern, name)
+
+	return matched
+}
+
+// common returns the common git directory of a linked working tree's git directory, or the directory itself.
+func common(gitDir string) string {
+	content, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+
+	dir := filepath.FromSlash(strings.TrimSpace(string(content)))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(gitDir, dir)
+	}
+
+	return dir
+}
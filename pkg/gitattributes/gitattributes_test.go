@@ -0,0 +1,133 @@
+package gitattributes_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/pkg/gitattributes"
+)
+
+func TestAttributes_Lookup(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		".gitattributes": `# Comment
+[attr]generated -diff whitespace
+*.txt text eol=lf
+*.bin binary
+/top.md whitespace=cr-at-eol
+docs/*.md -whitespace
+gen.go generated
+`,
+		"sub/.gitattributes": `*.txt !eol
+*.md whitespace
+deep/ text
+`,
+	}
+
+	read := func(name string) ([]byte, error) {
+		if content, ok := files[name]; ok {
+			return []byte(content), nil
+		}
+
+		return nil, fs.ErrNotExist
+	}
+
+	attributes := gitattributes.New(read, []byte("*.log -text\n"), []byte("*.log text\n*.md eol=crlf\n"))
+
+	tcs := []struct {
+		name     string              // Name of the test case (for logging)
+		path     string              // Path to look up
+		expected gitattributes.State // Attributes expected
+	}{
+		{
+			name:     "unspecified",
+			path:     "main.go",
+			expected: gitattributes.State{},
+		},
+		{
+			name:     "pattern without slash matches at any level",
+			path:     "a/b/c.txt",
+			expected: gitattributes.State{"text": "set", "eol": "lf"},
+		},
+		{
+			name:     "binary macro",
+			path:     "image.bin",
+			expected: gitattributes.State{"binary": "set", "diff": "unset", "merge": "unset", "text": "unset"},
+		},
+		{
+			name:     "anchored pattern",
+			path:     "top.md",
+			expected: gitattributes.State{"eol": "crlf", "whitespace": "cr-at-eol"},
+		},
+		{
+			name:     "anchored pattern does not match in subdirectories",
+			path:     "other/top.md",
+			expected: gitattributes.State{"eol": "crlf"},
+		},
+		{
+			name:     "pattern with slash is relative to its file",
+			path:     "docs/index.md",
+			expected: gitattributes.State{"eol": "crlf", "whitespace": "unset"},
+		},
+		{
+			name:     "custom macro",
+			path:     "pkg/gen.go",
+			expected: gitattributes.State{"generated": "set", "diff": "unset", "whitespace": "set"},
+		},
+		{
+			name:     "deeper files take precedence and unspecify",
+			path:     "sub/a.txt",
+			expected: gitattributes.State{"text": "set"},
+		},
+		{
+			name:     "deeper files take precedence",
+			path:     "sub/docs/index.md",
+			expected: gitattributes.State{"eol": "crlf", "whitespace": "set"},
+		},
+		{
+			name:     "directory patterns do not match",
+			path:     "sub/deep/file",
+			expected: gitattributes.State{},
+		},
+		{
+			name:     "info attributes take precedence over global attributes",
+			path:     "build.log",
+			expected: gitattributes.State{"text": "unset"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, attributes.Lookup(tc.path))
+		})
+	}
+}
+
+func TestRepository(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "info"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub", "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".git", "info", "attributes"), []byte("*.md -text\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", ".gitattributes"), []byte("*.md text\n*.txt text\n"), 0o600))
+
+	found, gitDir, ok := gitattributes.Repository(filepath.Join(root, "sub", "dir"))
+	require.True(t, ok)
+	require.Equal(t, root, found)
+	require.Equal(t, filepath.Join(root, ".git"), gitDir)
+
+	attributes := gitattributes.Load(found, gitDir)
+	require.Equal(t, gitattributes.State{"text": "unset"}, attributes.Lookup("sub/dir/README.md"))
+	require.Equal(t, gitattributes.State{"text": "set"}, attributes.Lookup("sub/dir/notes.txt"))
+
+	_, _, ok = gitattributes.Repository(filepath.VolumeName(root) + string(filepath.Separator))
+	require.False(t, ok)
+}
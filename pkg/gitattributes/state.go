@@ -0,0 +1,85 @@
+package gitattributes
+
+import (
+	"strings"
+)
+
+// Binary returns true if the path is marked as binary: by the binary macro, or by unsetting the text attribute.
+// Unsetting the diff attribute alone (e.g. for lock files) only disables textual diffs, and does not mark the
+// path as binary.
+func (s State) Binary() bool {
+	return s["text"] == Unset
+}
+
+// Text returns true if the path is marked as text, by setting the text attribute or the line endings
+// (eol), so that its content is not inspected to detect binary files.
+func (s State) Text() bool {
+	return s["text"] == Set || (s["eol"] != "" && s["text"] != Unset)
+}
+
+// Whitespace are the whitespace rules of git's core.whitespace setting and whitespace attribute
+// that have a counterpart in wslint.
+type Whitespace struct {
+	// BlankAtEOL reports whitespace at the end of lines.
+	BlankAtEOL bool
+	// SpaceBeforeTab reports spaces immediately before a tab in the indentation.
+	SpaceBeforeTab bool
+	// BlankAtEOF reports blank lines at the end of the file.
+	BlankAtEOF bool
+	// CRAtEOL treats a carriage return at the end of a line as part of the line ending, not as whitespace.
+	CRAtEOL bool
+}
+
+// DefaultWhitespace are the rules of wslint, applied to paths without the whitespace attribute.
+//
+//nolint:gochecknoglobals // Constant defaults.
+var DefaultWhitespace = Whitespace{BlankAtEOL: true, BlankAtEOF: true}
+
+// Whitespace returns the whitespace rules of the path, following git's semantics:
+//   - unspecified: the rules of wslint (DefaultWhitespace)
+//   - set: the default rules of git (blank-at-eol, space-before-tab and blank-at-eof)
+//   - unset: no rules
+//   - a comma separated list: the default rules of git, with the rules listed enabled or (prefixed with "-")
+//     disabled. "trailing-space" stands for both "blank-at-eol" and "blank-at-eof". Rules without
+//     a counterpart in wslint (e.g. indent-with-non-tab) are ignored.
+//
+// Lines ending with a carriage return are also accepted if the line endings are set to CRLF (eol=crlf).
+func (s State) Whitespace() Whitespace {
+	git := Whitespace{BlankAtEOL: true, SpaceBeforeTab: true, BlankAtEOF: true}
+
+	var rules Whitespace
+
+	switch value, ok := s["whitespace"]; {
+	case !ok:
+		rules = DefaultWhitespace
+	case value == Set:
+		rules = git
+	case value == Unset:
+	default:
+		rules = git
+
+		for _, rule := range strings.Split(value, ",") {
+			rule, disable := strings.CutPrefix(strings.TrimSpace(rule), "-")
+			enable := !disable
+
+			switch rule {
+			case "trailing-space":
+				rules.BlankAtEOL, rules.BlankAtEOF = enable, enable
+			case "blank-at-eol":
+				rules.BlankAtEOL = enable
+			case "blank-at-eof":
+				rules.BlankAtEOF = enable
+			case "space-before-tab":
+				rules.SpaceBeforeTab = enable
+			case "cr-at-eol":
+				rules.CRAtEOL = enable
+			}
+		}
+	}
+
+	if s["eol"] == "crlf" {
+		rules.CRAtEOL = true
+	}
+
+	return rules
+}
@@ -0,0 +1,79 @@
+package gitattributes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/pkg/gitattributes"
+)
+
+func TestState(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string                   // Name of the test case (for logging)
+		state      gitattributes.State      // Attributes of the path
+		binary     bool                     // Whether the path is expected to be binary
+		text       bool                     // Whether the path is expected to be text
+		whitespace gitattributes.Whitespace // Whitespace rules expected
+	}{
+		{
+			name:       "unspecified",
+			state:      gitattributes.State{},
+			whitespace: gitattributes.DefaultWhitespace,
+		},
+		{
+			name:       "binary",
+			state:      gitattributes.State{"binary": "set", "diff": "unset", "merge": "unset", "text": "unset"},
+			binary:     true,
+			whitespace: gitattributes.DefaultWhitespace,
+		},
+		{
+			name:       "text unset",
+			state:      gitattributes.State{"text": "unset"},
+			binary:     true,
+			whitespace: gitattributes.DefaultWhitespace,
+		},
+		{
+			name:       "diff unset",
+			state:      gitattributes.State{"diff": "unset"},
+			whitespace: gitattributes.DefaultWhitespace,
+		},
+		{
+			name:       "text with CRLF line endings",
+			state:      gitattributes.State{"eol": "crlf"},
+			text:       true,
+			whitespace: gitattributes.Whitespace{BlankAtEOL: true, BlankAtEOF: true, CRAtEOL: true},
+		},
+		{
+			name:       "whitespace set",
+			state:      gitattributes.State{"whitespace": "set"},
+			whitespace: gitattributes.Whitespace{BlankAtEOL: true, SpaceBeforeTab: true, BlankAtEOF: true},
+		},
+		{
+			name:  "whitespace unset",
+			state: gitattributes.State{"whitespace": "unset"},
+		},
+		{
+			name:       "whitespace rules",
+			state:      gitattributes.State{"whitespace": "-trailing-space,cr-at-eol,indent-with-non-tab"},
+			whitespace: gitattributes.Whitespace{SpaceBeforeTab: true, CRAtEOL: true},
+		},
+		{
+			name:       "whitespace rules disabling defaults",
+			state:      gitattributes.State{"whitespace": "-space-before-tab, -blank-at-eof"},
+			whitespace: gitattributes.Whitespace{BlankAtEOL: true},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.binary, tc.state.Binary())
+			require.Equal(t, tc.text, tc.state.Text())
+			require.Equal(t, tc.whitespace, tc.state.Whitespace())
+		})
+	}
+}
@@ -1,5 +1,8 @@
 // Package matcher provides a utility for matching files based on glob patterns, with support for
 // excluding directories, hidden files and folders, and binary files.
+//
+// Files in git repositories are classified as text or binary according to the text, binary and eol
+// attributes assigned by the .gitattributes files, and by inspecting their content otherwise.
 package matcher
 
 import (
@@ -8,6 +11,8 @@ import (
 	"path/filepath"
 
 	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/idelchi/wslint/pkg/gitattributes"
 )
 
 // Logger is an interface for logging formatted messages.
@@ -26,6 +31,14 @@ type Globber struct {
 	files []string
 	// extraExcludes functions
 	extraExcludes map[string]func(string) bool
+	// repositories caches the repository containing each directory (nil outside of repositories).
+	repositories map[string]*repository
+}
+
+// repository is a git working tree, along with its attributes.
+type repository struct {
+	root       string
+	attributes *gitattributes.Attributes
 }
 
 // ListFiles lists all files found by the Globber.
@@ -110,6 +123,18 @@ outer:
 		case IsExcluded(match, m.Exclude) != "":
 			m.Logger.Printf("<skipped> %q <matches exclude pattern> %q", match, IsExcluded(match, m.Exclude))
 		default:
+			switch attributes := m.Attributes(match); {
+			case attributes.Binary():
+				m.Logger.Printf("<skipped> %q <binary according to .gitattributes>", match)
+
+				continue outer
+			// Files marked as text are not inspected.
+			case attributes.Text():
+				m.files = append(m.files, match)
+
+				continue outer
+			}
+
 			for name, fn := range m.extraExcludes {
 				if fn(match) {
 					m.Logger.Printf("<skipped> %q <%s>", match, name)
@@ -124,3 +149,48 @@ outer:
 
 	return nil
 }
+
+// Attributes returns the git attributes of the file, as assigned by the .gitattributes files of the
+// repository containing it. Files outside of repositories have no attributes.
+func (m *Globber) Attributes(file string) gitattributes.State {
+	file, _ = filepath.Abs(file)
+
+	repo := m.repository(filepath.Dir(file))
+	if repo == nil {
+		return gitattributes.State{}
+	}
+
+	rel, err := filepath.Rel(repo.root, file)
+	if err != nil {
+		return gitattributes.State{}
+	}
+
+	return repo.attributes.Lookup(filepath.ToSlash(rel))
+}
+
+// repository returns the repository containing the directory, or nil if there is none.
+func (m *Globber) repository(dir string) *repository {
+	if repo, ok := m.repositories[dir]; ok {
+		return repo
+	}
+
+	if m.repositories == nil {
+		m.repositories = make(map[string]*repository)
+	}
+
+	var repo *repository
+
+	if root, gitDir, ok := gitattributes.Repository(dir); ok {
+		// Share the attributes between all directories of the repository.
+		if existing, ok := m.repositories[root]; ok && existing != nil && existing.root == root {
+			repo = existing
+		} else {
+			repo = &repository{root: root, attributes: gitattributes.Load(root, gitDir)}
+			m.repositories[root] = repo
+		}
+	}
+
+	m.repositories[dir] = repo
+
+	return repo
+}
@@ -57,6 +57,7 @@ func TestGlobber_Match(t *testing.T) {
 		content  []string // Content of each file
 		expected []string // List of files that should be found by the globber
 		hidden   bool     // Whether to allow the globber to match hidden files
+		repo     bool     // Whether the temporary folder is a git repository
 		comment  string   // Comment in case of failure
 	}{
 		{
@@ -89,6 +90,21 @@ func TestGlobber_Match(t *testing.T) {
 			expected: []string{".test.txt"},
 			hidden:   true,
 		},
+		{
+			comment:  "Expected to find only the empty file, since it is marked as text while the other is marked as binary",
+			name:     "Files classified by .gitattributes",
+			files:    []string{"test.dat", "test.txt", ".gitattributes"},
+			content:  []string{"", "test", "*.dat text\n*.txt binary\n"},
+			expected: []string{"test.dat"},
+			repo:     true,
+		},
+		{
+			comment:  "Expected to find no file, since .gitattributes outside of a repository are ignored",
+			name:     "Files outside of a repository",
+			files:    []string{"test.dat", ".gitattributes"},
+			content:  []string{"", "*.dat text\n"},
+			expected: []string{},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -104,6 +120,10 @@ func TestGlobber_Match(t *testing.T) {
 				tc.expected[i] = filepath.ToSlash(filepath.Join(dir, tc.expected[i]))
 			}
 
+			if tc.repo {
+				require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+			}
+
 			// Create the files.
 			for i := range tc.files {
 				_ = CreateTempFile(t, dir, tc.files[i], tc.content[i])
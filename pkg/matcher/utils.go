@@ -18,6 +18,11 @@ func IsBinary(file string) bool {
 	return !util.IsTextFile(fs, filepath.Base(file))
 }
 
+// IsBinaryContent returns true if the given content (of a file not read from disk) is detected as binary, false otherwise.
+func IsBinaryContent(content []byte) bool {
+	return !util.IsText(content)
+}
+
 // IsExplicitlyIncluded returns true if the given file is considered to be explicitly included, which
 // means the full pattern and the filename do not contain any glob characters.
 func IsExplicitlyIncluded(file string) bool {
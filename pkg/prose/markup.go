@@ -0,0 +1,189 @@
+package prose
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// marker matches the markers starting a block of its own: headings ("#" or "="), bullet and numbered list items,
+// and table rows.
+//
+//nolint:gochecknoglobals // The pattern is compiled once.
+var marker = regexp.MustCompile(`^(?:(?:#{1,6}|={1,6}|[-*+.]|\d{1,9}[.)])(?:[ \t]|$)|\|)`)
+
+// markupLexer finds the text outside of fenced code blocks and inline code.
+type markupLexer struct {
+	// open returns the fence opened by the line, if any, along with a function reporting whether a line closes it.
+	open func(line string) (fence string, closes func(line string) bool)
+	// closes reports whether a line closes the current fence, if inside of a fenced block.
+	closes func(line string) bool
+}
+
+// Next returns the text of the line outside of inline code, or nothing for the lines of a fenced block.
+func (l *markupLexer) Next(line []byte) [][2]int {
+	if l.closes != nil {
+		if l.closes(string(line)) {
+			l.closes = nil
+		}
+
+		return nil
+	}
+
+	if fence, closes := l.open(string(line)); fence != "" {
+		l.closes = closes
+
+		return nil
+	}
+
+	return inline(line)
+}
+
+// markdownFence returns the fence opened by a line of Markdown: at least three backticks or tildes, indented by
+// at most three spaces. The fence is closed by a line with at least as many of the same characters.
+func markdownFence(line string) (string, func(string) bool) {
+	fence := fenceRun(strings.TrimLeft(line, " "), "`~")
+	if fence == "" || len(line)-len(strings.TrimLeft(line, " ")) > 3 {
+		return "", nil
+	}
+
+	return fence, func(line string) bool {
+		trimmed := strings.TrimSpace(line)
+
+		return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == ""
+	}
+}
+
+// asciidocFence returns the fence opened by a line of AsciiDoc: a listing ("----") or literal ("....")
+// block delimiter, or a Markdown style code fence. The block is closed by the same delimiter.
+func asciidocFence(line string) (string, func(string) bool) {
+	trimmed := strings.TrimRight(line, " \t")
+
+	for _, delimiter := range []string{"----", "...."} {
+		if len(trimmed) >= len(delimiter) && strings.Trim(trimmed, delimiter[:1]) == "" {
+			return trimmed, func(line string) bool { return strings.TrimRight(line, " \t") == trimmed }
+		}
+	}
+
+	if fence := fenceRun(trimmed, "`"); fence != "" {
+		return fence, func(line string) bool { return strings.TrimSpace(line) == fence }
+	}
+
+	return "", nil
+}
+
+// fenceRun returns the run of at least three identical characters (among chars) starting the line, if any.
+func fenceRun(line, chars string) string {
+	if line == "" || !strings.ContainsRune(chars, rune(line[0])) {
+		return ""
+	}
+
+	run := len(line) - len(strings.TrimLeft(line, line[:1]))
+	if run < 3 { //nolint:mnd // Fences are at least three characters long.
+		return ""
+	}
+
+	return line[:run]
+}
+
+// rstLexer finds the text outside of literal blocks and inline literals in reStructuredText.
+type rstLexer struct {
+	// literal is set after a line introducing a literal block (ending with "::"), for as long as the
+	// following lines are blank or indented.
+	literal bool
+}
+
+// Next returns the text of the line outside of inline literals, or nothing for the lines of a literal block.
+func (l *rstLexer) Next(line []byte) [][2]int {
+	if l.literal {
+		if len(bytes.TrimSpace(line)) == 0 || line[0] == ' ' || line[0] == '\t' {
+			return nil
+		}
+
+		l.literal = false
+	}
+
+	trimmed := bytes.TrimSpace(line)
+
+	// Code directives (".. code-block:: go") have arguments after the "::".
+	if bytes.HasSuffix(trimmed, []byte("::")) || bytes.HasPrefix(trimmed, []byte(".. code")) ||
+		bytes.HasPrefix(trimmed, []byte(".. sourcecode")) {
+		l.literal = true
+	}
+
+	// Directives and comments.
+	if bytes.HasPrefix(trimmed, []byte(".. ")) || bytes.Equal(trimmed, []byte("..")) {
+		return nil
+	}
+
+	return inline(line)
+}
+
+// inline returns the ranges of the line outside of inline code, delimited by runs of backticks of the same length.
+// Unmatched backticks are part of the text.
+func inline(line []byte) (spans [][2]int) {
+	start := 0
+
+	for position := 0; position < len(line); {
+		if line[position] != '`' {
+			position++
+
+			continue
+		}
+
+		run := len(line[position:]) - len(bytes.TrimLeft(line[position:], "`"))
+		end := closing(line, position+run, run)
+
+		if end < 0 {
+			position += run
+
+			continue
+		}
+
+		if position > start {
+			spans = append(spans, [2]int{start, position})
+		}
+
+		start, position = end, end
+	}
+
+	if start < len(line) {
+		spans = append(spans, [2]int{start, len(line)})
+	}
+
+	return spans
+}
+
+// closing returns the position after the run of exactly run backticks closing inline code opened before from,
+// or -1 if there is none.
+func closing(line []byte, from, run int) int {
+	for position := from; position < len(line); {
+		if line[position] != '`' {
+			position++
+
+			continue
+		}
+
+		length := len(line[position:]) - len(bytes.TrimLeft(line[position:], "`"))
+		if length == run {
+			return position + length
+		}
+
+		position += length
+	}
+
+	return -1
+}
+
+// Block returns true if the prose, stripped of the indentation and delimiters of its line, is a block of its own
+// in markup: a heading, a list item, a table row, or the underline of a heading (or a thematic break).
+// Such a line does not continue the prose of the previous line, nor is it continued by the next one.
+func Block(text []byte) bool {
+	if marker.Match(text) {
+		return true
+	}
+
+	trimmed := string(bytes.TrimRight(text, " \t"))
+
+	return fenceRun(trimmed, "=-~^*#+_:'\"`.") == trimmed && trimmed != ""
+}
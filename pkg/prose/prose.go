@@ -0,0 +1,140 @@
+// Package prose finds the prose in text files, to restrict checks meant for natural language to it.
+//
+// In markup formats (e.g. Markdown), the prose is the text outside of code blocks and inline code.
+// In source files, the prose is the content of the comments and string literals, as found by lightweight
+// lexers that only know the comment and string syntax of the language. In any other file, all text is prose.
+//
+// The lexers process the lines one at a time, so that they can be used on streamed content.
+package prose
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Syntax identifies how the prose is found in a file.
+type Syntax string
+
+// The supported syntaxes.
+const (
+	// Text treats all text as prose.
+	Text Syntax = "text"
+	// Markdown excludes fenced code blocks and inline code.
+	Markdown Syntax = "markdown"
+	// ReStructuredText excludes literal blocks, code directives and inline literals.
+	ReStructuredText Syntax = "rst"
+	// AsciiDoc excludes listing and literal blocks, fenced code blocks and inline code.
+	AsciiDoc Syntax = "asciidoc"
+	// Go includes comments, interpreted and raw strings.
+	Go Syntax = "go"
+	// Python includes comments and (triple-quoted) strings.
+	Python Syntax = "python"
+	// Shell includes comments and quoted strings.
+	Shell Syntax = "shell"
+	// YAML includes comments and quoted strings.
+	YAML Syntax = "yaml"
+	// JavaScript includes comments, strings and template literals (also used for TypeScript).
+	JavaScript Syntax = "javascript"
+	// C includes comments and strings of the C family of languages (e.g. C, C++, Java, C#, Rust).
+	C Syntax = "c"
+)
+
+// Lexer finds the prose in a sequence of lines, one line at a time.
+type Lexer interface {
+	// Next returns the byte ranges [start, end) of the prose in the next line, in order.
+	Next(line []byte) [][2]int
+}
+
+// extensions maps the (lower-case) file extensions to their syntax.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var extensions = map[string]Syntax{
+	".txt":      Text,
+	".md":       Markdown,
+	".markdown": Markdown,
+	".rst":      ReStructuredText,
+	".adoc":     AsciiDoc,
+	".asciidoc": AsciiDoc,
+	".go":       Go,
+	".py":       Python,
+	".pyi":      Python,
+	".sh":       Shell,
+	".bash":     Shell,
+	".zsh":      Shell,
+	".ksh":      Shell,
+	".yml":      YAML,
+	".yaml":     YAML,
+	".js":       JavaScript,
+	".jsx":      JavaScript,
+	".mjs":      JavaScript,
+	".cjs":      JavaScript,
+	".ts":       JavaScript,
+	".tsx":      JavaScript,
+	".mts":      JavaScript,
+	".cts":      JavaScript,
+	".c":        C,
+	".h":        C,
+	".cc":       C,
+	".cpp":      C,
+	".cxx":      C,
+	".hh":       C,
+	".hpp":      C,
+	".java":     C,
+	".cs":       C,
+	".rs":       C,
+	".swift":    C,
+	".kt":       C,
+	".kts":      C,
+	".scala":    C,
+}
+
+// Detect returns the syntax of a file, from the extension of its name.
+// It returns Text for unknown extensions.
+func Detect(name string) Syntax {
+	if syntax, ok := extensions[strings.ToLower(filepath.Ext(name))]; ok {
+		return syntax
+	}
+
+	return Text
+}
+
+// Delimiters returns the characters that may precede the prose of a line without interrupting it: the comment
+// delimiters of source code. Markup and text have none, as the markers of their blocks (e.g. headings or list
+// items) start a new run of prose.
+func (s Syntax) Delimiters() string {
+	return leaders[s]
+}
+
+// Markup returns true if the syntax is a markup language, whose blank lines separate blocks of prose.
+func (s Syntax) Markup() bool {
+	return s == Markdown || s == ReStructuredText || s == AsciiDoc
+}
+
+// Lexer returns a new lexer for the syntax, to process the lines of a single file.
+// Unknown syntaxes are treated as Text.
+func (s Syntax) Lexer() Lexer {
+	switch s {
+	case Markdown:
+		return &markupLexer{open: markdownFence}
+	case AsciiDoc:
+		return &markupLexer{open: asciidocFence}
+	case ReStructuredText:
+		return &rstLexer{}
+	case Text:
+		return text{}
+	}
+
+	if tokens, ok := languages[s]; ok {
+		return &sourceLexer{tokens: tokens}
+	}
+
+	return text{}
+}
+
+// text treats whole lines as prose.
+type text struct{}
+
+// Next returns the whole line.
+func (text) Next(line []byte) [][2]int {
+	return [][2]int{{0, len(line)}}
+}
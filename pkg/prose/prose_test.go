@@ -0,0 +1,215 @@
+package prose_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/pkg/prose"
+)
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string       // Name of the file
+		expected prose.Syntax // Expected syntax
+	}{
+		{name: "README.md", expected: prose.Markdown},
+		{name: "docs/index.RST", expected: prose.ReStructuredText},
+		{name: "main.go", expected: prose.Go},
+		{name: "src/app.tsx", expected: prose.JavaScript},
+		{name: "lib.hpp", expected: prose.C},
+		{name: ".github/workflows/ci.yml", expected: prose.YAML},
+		{name: "Makefile", expected: prose.Text},
+		{name: "notes.txt", expected: prose.Text},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, prose.Detect(tc.name))
+		})
+	}
+}
+
+func TestSyntax_Delimiters(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		syntax   prose.Syntax // Syntax of the file
+		expected string       // Expected delimiters
+	}{
+		{syntax: prose.Go, expected: "/*"},
+		{syntax: prose.Python, expected: "#"},
+		{syntax: prose.YAML, expected: "#"},
+		{syntax: prose.Markdown, expected: ""},
+		{syntax: prose.ReStructuredText, expected: ""},
+		{syntax: prose.Text, expected: ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(string(tc.syntax), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, tc.syntax.Delimiters())
+		})
+	}
+}
+
+func TestBlock(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		text     string // Prose of the line
+		expected bool   // Whether the line is a block of its own
+	}{
+		{text: "# Setup", expected: true},
+		{text: "== Section", expected: true},
+		{text: "- the item", expected: true},
+		{text: "* the item", expected: true},
+		{text: "12. the item", expected: true},
+		{text: "| a | b |", expected: true},
+		{text: "=====", expected: true},
+		{text: "---  ", expected: true},
+		{text: "Setup the server.", expected: false},
+		{text: "#hashtag", expected: false},
+		{text: "-1 is returned", expected: false},
+		{text: "", expected: false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.text, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, prose.Block([]byte(tc.text)))
+		})
+	}
+}
+
+func TestSyntax_Lexer(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string       // Name of the test case (for logging)
+		syntax   prose.Syntax // Syntax to lex
+		lines    []string     // Lines to lex
+		expected []string     // Prose found in each line, joined with "|"
+	}{
+		{
+			name:     "text",
+			syntax:   prose.Text,
+			lines:    []string{"x x = 1", ""},
+			expected: []string{"x x = 1", ""},
+		},
+		{
+			name:   "markdown",
+			syntax: prose.Markdown,
+			lines: []string{
+				"Use `func func` or ``a ` b`` here, not `this",
+				"```go",
+				"func func()",
+				"````",
+				"~~~",
+				"```",
+				"~~~~",
+				"after",
+			},
+			expected: []string{"Use | or | here, not `this", "", "", "", "", "", "", "after"},
+		},
+		{
+			name:   "asciidoc",
+			syntax: prose.AsciiDoc,
+			lines:  []string{"text `code`", "----", "code", "----", "....", "literal", "....", "text"},
+			expected: []string{
+				"text ", "", "", "", "", "", "", "text",
+			},
+		},
+		{
+			name:   "restructuredtext",
+			syntax: prose.ReStructuredText,
+			lines: []string{
+				"An example::",
+				"",
+				"    x x",
+				"",
+				"Text with ``a a`` literal.",
+				".. code-block:: go",
+				"",
+				"    func func()",
+				"Text",
+			},
+			expected: []string{"An example::", "", "", "", "Text with | literal.", "", "", "", "Text"},
+		},
+		{
+			name:   "go",
+			syntax: prose.Go,
+			lines: []string{
+				`x := "a \"b\" c" + 'x' // the comment`,
+				"/* block",
+				"still */ y := `raw",
+				"string` // end",
+				`s := '"' + "//"`,
+			},
+			expected: []string{`a \"b\" c| the comment`, " block", "still |raw", "string| end", "//"},
+		},
+		{
+			name:   "python",
+			syntax: prose.Python,
+			lines: []string{
+				`def f(): # the comment`,
+				`    """Doc`,
+				`    string."""`,
+				`    return 'a' + "b"`,
+			},
+			expected: []string{" the comment", "Doc", "    string.", "a|b"},
+		},
+		{
+			name:     "shell",
+			syntax:   prose.Shell,
+			lines:    []string{`echo "$# args" 'it' # comment`, `x=${#y}`},
+			expected: []string{"$# args|it| comment", ""},
+		},
+		{
+			name:     "yaml",
+			syntax:   prose.YAML,
+			lines:    []string{`key: "value # not" # comment`, "url: http://x#y"},
+			expected: []string{"value # not| comment", ""},
+		},
+		{
+			name:     "javascript",
+			syntax:   prose.JavaScript,
+			lines:    []string{"const s = 'a' + `b", "c` // d"},
+			expected: []string{"a|b", "c| d"},
+		},
+		{
+			name:     "c",
+			syntax:   prose.C,
+			lines:    []string{`char c = '"'; /* a */ printf("b");`},
+			expected: []string{" a |b"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lexer := tc.syntax.Lexer()
+
+			for i, line := range tc.lines {
+				var found string
+
+				for j, span := range lexer.Next([]byte(line)) {
+					if j > 0 {
+						found += "|"
+					}
+
+					found += line[span[0]:span[1]]
+				}
+
+				require.Equal(t, tc.expected[i], found, "line %d: %q", i+1, line)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package prose
+
+import "bytes"
+
+// token describes the delimiters of a comment or a string literal.
+type token struct {
+	// open and close delimit the token. An empty close ends the token at the end of the line.
+	open, close string
+	// word requires the opening delimiter to start a word (e.g. "#" in shell scripts, but not in "$#").
+	word bool
+	// escape allows escaping the closing delimiter with a backslash.
+	escape bool
+	// multiline allows the token to continue on the next lines.
+	multiline bool
+	// code marks tokens that are not prose (e.g. character literals).
+	code bool
+}
+
+// Tokens shared by several languages.
+//
+//nolint:gochecknoglobals // Read-only building blocks of the lookup table.
+var (
+	slashComment = token{open: "//"}
+	blockComment = token{open: "/*", close: "*/", multiline: true}
+	hashComment  = token{open: "#", word: true}
+	doubleQuoted = token{open: `"`, close: `"`, escape: true}
+	singleQuoted = token{open: "'", close: "'", escape: true}
+	character    = token{open: "'", close: "'", escape: true, code: true}
+)
+
+// languages maps the source syntaxes to their tokens, in order of precedence
+// (e.g. triple quotes before single quotes).
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var languages = map[Syntax][]token{
+	Go: {slashComment, blockComment, doubleQuoted, {open: "`", close: "`", multiline: true}, character},
+	C:  {slashComment, blockComment, doubleQuoted, character},
+	JavaScript: {
+		slashComment, blockComment, doubleQuoted, singleQuoted,
+		{open: "`", close: "`", escape: true, multiline: true},
+	},
+	Python: {
+		{open: "#"},
+		{open: `"""`, close: `"""`, escape: true, multiline: true},
+		{open: "'''", close: "'''", escape: true, multiline: true},
+		doubleQuoted, singleQuoted,
+	},
+	Shell: {
+		hashComment,
+		{open: `"`, close: `"`, escape: true, multiline: true},
+		{open: "'", close: "'", multiline: true},
+	},
+	YAML: {
+		hashComment,
+		{open: `"`, close: `"`, escape: true, multiline: true},
+		{open: "'", close: "'", multiline: true},
+	},
+}
+
+// leaders maps the source syntaxes to the characters of their comment delimiters, which may also decorate the
+// lines of a comment (e.g. " * " in block comments, or "##" in shell scripts).
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var leaders = map[Syntax]string{
+	Go:         "/*",
+	C:          "/*",
+	JavaScript: "/*",
+	Python:     "#",
+	Shell:      "#",
+	YAML:       "#",
+}
+
+// sourceLexer finds the comments and string literals in source code.
+type sourceLexer struct {
+	tokens []token
+	// open is the multiline token continuing on the next line, if any.
+	open *token
+}
+
+// Next returns the content of the comments and string literals in the line, without their delimiters.
+func (l *sourceLexer) Next(line []byte) (spans [][2]int) {
+	position := 0
+
+	if l.open != nil {
+		current := l.open
+		l.open = nil
+
+		if position, spans = l.scan(current, line, 0, spans); position < 0 {
+			return spans
+		}
+	}
+
+	for position < len(line) {
+		current := l.match(line, position)
+		if current == nil {
+			position++
+
+			continue
+		}
+
+		if position, spans = l.scan(current, line, position+len(current.open), spans); position < 0 {
+			return spans
+		}
+	}
+
+	return spans
+}
+
+// match returns the token opening at the position, if any.
+func (l *sourceLexer) match(line []byte, position int) *token {
+	for i := range l.tokens {
+		current := &l.tokens[i]
+
+		if !bytes.HasPrefix(line[position:], []byte(current.open)) {
+			continue
+		}
+
+		if current.word && position > 0 && line[position-1] != ' ' && line[position-1] != '\t' {
+			continue
+		}
+
+		return current
+	}
+
+	return nil
+}
+
+// scan scans the content of the token from start, appends it to the spans unless it is code, and returns
+// the position after the closing delimiter. It returns -1 if the token extends to the end of the line,
+// keeping multiline tokens open for the next line.
+func (l *sourceLexer) scan(current *token, line []byte, start int, spans [][2]int) (int, [][2]int) {
+	end, next := len(line), -1
+
+	if current.close != "" {
+		for i := start; i < len(line); i++ {
+			if current.escape && line[i] == '\\' {
+				i++
+
+				continue
+			}
+
+			if bytes.HasPrefix(line[i:], []byte(current.close)) {
+				end, next = i, i+len(current.close)
+
+				break
+			}
+		}
+
+		if next < 0 && current.multiline {
+			l.open = current
+		}
+	}
+
+	if !current.code && end > start {
+		spans = append(spans, [2]int{start, end})
+	}
+
+	return next, spans
+}
@@ -0,0 +1,185 @@
+package stuttering
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ErrInvalidException is returned when an exceptions file cannot be parsed.
+var ErrInvalidException = errors.New("invalid exception")
+
+// Exception is a repeated word or phrase that is not reported.
+// Exceptions are matched against the normalized phrase of a repetition (see Repetition.Phrase),
+// e.g. "that" for "That that." or "in the" for "in the in the".
+type Exception struct {
+	// Phrase is the normalized phrase to match exactly, if Pattern is nil.
+	Phrase string
+	// Pattern is the regular expression that must match the whole normalized phrase, if set.
+	Pattern *regexp.Regexp
+	// Scope lists the glob patterns of the paths the exception applies to. It applies to all paths if empty.
+	Scope []string
+}
+
+// Matches returns true if the exception matches the normalized phrase.
+func (e Exception) Matches(phrase string) bool {
+	if e.Pattern != nil {
+		return e.Pattern.MatchString(phrase)
+	}
+
+	return e.Phrase == phrase
+}
+
+// Applies returns true if the exception applies to the file with the given path.
+// The path is matched against the glob patterns of the scope with "./" removed.
+func (e Exception) Applies(path string) bool {
+	if len(e.Scope) == 0 {
+		return true
+	}
+
+	path = strings.TrimPrefix(filepath.ToSlash(path), "./")
+
+	for _, pattern := range e.Scope {
+		if matched, _ := doublestar.Match(pattern, path); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Exceptions is a list of exceptions.
+type Exceptions []Exception
+
+// Match returns true if any of the exceptions matches the normalized phrase, regardless of their scope.
+func (e Exceptions) Match(phrase string) bool {
+	for _, exception := range e {
+		if exception.Matches(phrase) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// For returns the exceptions that apply to the file with the given path.
+func (e Exceptions) For(path string) (exceptions Exceptions) {
+	for _, exception := range e {
+		if exception.Applies(path) {
+			exceptions = append(exceptions, exception)
+		}
+	}
+
+	return exceptions
+}
+
+// ParseExceptions reads exceptions from r, one per line:
+//
+//	# Comments and blank lines are ignored.
+//	that                  a word, matched against the normalized phrase
+//	in the                a phrase
+//	(Logger Logger)       a stutter as reported, reduced to its normalized phrase ("logger")
+//	/^v\d+$/              a regular expression, matching the whole normalized phrase
+//
+//	[docs/**, *.md]       the following exceptions only apply to the paths matching the glob patterns
+//	[*]                   the following exceptions apply to all paths again
+//
+// It returns ErrInvalidException for invalid regular expressions or glob patterns.
+func ParseExceptions(r io.Reader) (Exceptions, error) {
+	var (
+		exceptions Exceptions
+		scope      []string
+	)
+
+	scanner := bufio.NewScanner(r)
+
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			var err error
+			if scope, err = parseScope(line[1 : len(line)-1]); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidException, number, err)
+			}
+
+			continue
+		}
+
+		exception, err := parseException(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidException, number, err)
+		}
+
+		exception.Scope = scope
+		exceptions = append(exceptions, exception)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading exceptions: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+// parseScope parses the comma separated glob patterns of a scope, where "*" applies to all paths.
+func parseScope(patterns string) ([]string, error) {
+	var scope []string
+
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "./")
+
+		switch {
+		case pattern == "":
+			continue
+		case pattern == "*":
+			return nil, nil
+		case !doublestar.ValidatePattern(pattern):
+			return nil, fmt.Errorf("%w: %q", doublestar.ErrBadPattern, pattern)
+		}
+
+		scope = append(scope, pattern)
+	}
+
+	return scope, nil
+}
+
+// parseException parses a single exception.
+func parseException(line string) (Exception, error) {
+	// Regular expressions
+	if len(line) > 1 && strings.HasPrefix(line, "/") && strings.HasSuffix(line, "/") {
+		pattern, err := regexp.Compile(`^(?:` + line[1:len(line)-1] + `)$`)
+		if err != nil {
+			return Exception{}, err //nolint:wrapcheck // The error is wrapped by the caller.
+		}
+
+		return Exception{Pattern: pattern}, nil
+	}
+
+	reported := strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")")
+	if reported {
+		line = line[1 : len(line)-1]
+	}
+
+	words := tokenizeIndex(line)
+
+	// Stutters as reported, e.g. "(hello hello!)", are reduced to their first half.
+	if reported && len(words) > 1 {
+		words = words[:len(words)/2]
+	}
+
+	phrase := make([]string, len(words))
+	for i, word := range words {
+		phrase[i] = strings.ToLower(core(line[word[0]:word[1]]))
+	}
+
+	return Exception{Phrase: strings.Join(phrase, " ")}, nil
+}
@@ -0,0 +1,78 @@
+package stuttering_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/pkg/stuttering"
+)
+
+func TestParseExceptions(t *testing.T) {
+	t.Parallel()
+
+	const file = `# Comment
+
+that
+In the
+(Logger Logger)
+/v\d+/
+
+[docs/**, ./*.md]
+bye
+[*]
+ok
+`
+
+	exceptions, err := stuttering.ParseExceptions(strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, exceptions, 6)
+
+	tcs := []struct {
+		name    string // Name of the test case (for logging)
+		path    string // Path of the file
+		phrase  string // Normalized phrase of the repetition
+		ignored bool   // Whether the repetition is ignored
+	}{
+		{name: "word", path: "main.go", phrase: "that", ignored: true},
+		{name: "phrase", path: "main.go", phrase: "in the", ignored: true},
+		{name: "reported stutter", path: "main.go", phrase: "logger", ignored: true},
+		{name: "regular expression", path: "main.go", phrase: "v2", ignored: true},
+		{name: "partial regular expression", path: "main.go", phrase: "v2a"},
+		{name: "unknown phrase", path: "main.go", phrase: "the"},
+		{name: "scoped in scope", path: "docs/guide/intro.txt", phrase: "bye", ignored: true},
+		{name: "scoped in scope with prefix", path: "./README.md", phrase: "bye", ignored: true},
+		{name: "scoped out of scope", path: "main.go", phrase: "bye"},
+		{name: "scope reset", path: "main.go", phrase: "ok", ignored: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.ignored, exceptions.For(tc.path).Match(tc.phrase))
+		})
+	}
+}
+
+func TestParseExceptions_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name string // Name of the test case (for logging)
+		file string // Content of the exceptions file
+	}{
+		{name: "invalid regular expression", file: "that\n/(/\n"},
+		{name: "invalid glob pattern", file: "[docs/[a]\n"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := stuttering.ParseExceptions(strings.NewReader(tc.file))
+			require.ErrorIs(t, err, stuttering.ErrInvalidException)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package stuttering
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrUnknownLanguage is returned when exceptions are requested for a language without an exception set.
+var ErrUnknownLanguage = errors.New("unknown language")
+
+// languages holds the repetitions that are legitimate in a language, as normalized phrases (see Repetition.Phrase),
+// by ISO 639-1 language code.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var languages = map[string][]string{
+	// "I know that that is true", "she had had enough", "what it is is a problem", ...
+	"en": {"that", "had", "is", "bye", "so", "no"},
+	// "die Frauen, die die Kinder sehen", "das Haus, das das Dach hat", ...
+	"de": {"die", "der", "das", "den", "dem", "sie", "wer"},
+	// "nous nous voyons", "vous vous trompez", ...
+	"fr": {"nous", "vous", "si"},
+	// "die die", "dat dat", ...
+	"nl": {"die", "dat"},
+}
+
+// Languages returns the codes of the languages with an exception set, sorted.
+func Languages() []string {
+	return slices.Sorted(maps.Keys(languages))
+}
+
+// LanguageExceptions returns the exceptions for the phrases that are legitimately repeated in the given languages
+// (e.g. "that" for "that that" in English).
+// It returns ErrUnknownLanguage for languages without an exception set.
+func LanguageExceptions(codes ...string) (Exceptions, error) {
+	var exceptions Exceptions
+
+	for _, code := range codes {
+		phrases, ok := languages[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q (available: %v)", ErrUnknownLanguage, code, Languages())
+		}
+
+		for _, phrase := range phrases {
+			exceptions = append(exceptions, Exception{Phrase: phrase})
+		}
+	}
+
+	return exceptions, nil
+}
@@ -0,0 +1,52 @@
+package stuttering_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/pkg/stuttering"
+)
+
+func TestLanguageExceptions(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name      string   // Name of the test case (for logging)
+		languages []string // Languages to select
+		contains  []string // Phrases expected among the exceptions
+		err       error    // Error that should be returned
+	}{
+		{
+			name: "no language",
+		},
+		{
+			name:      "single language",
+			languages: []string{"en"},
+			contains:  []string{"that", "had"},
+		},
+		{
+			name:      "several languages",
+			languages: []string{"en", "de"},
+			contains:  []string{"that", "die"},
+		},
+		{
+			name:      "unknown language",
+			languages: []string{"en", "xx"},
+			err:       stuttering.ErrUnknownLanguage,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			exceptions, err := stuttering.LanguageExceptions(tc.languages...)
+			require.ErrorIs(t, err, tc.err)
+
+			for _, phrase := range tc.contains {
+				require.True(t, exceptions.Match(phrase), "phrase %q", phrase)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package stuttering
+
+import (
+	"slices"
+	"strings"
+)
+
+// DefaultLength is the default maximum number of words of a repeated phrase.
+const DefaultLength = 3
+
+// Repetition is a word or a phrase that is immediately repeated in a string.
+type Repetition struct {
+	// Stutter is the repetition, formatted as "(first second)", e.g. "(in the in the)".
+	Stutter string
+	// Phrase is the normalized repeated word or phrase (the lower-case cores of the words), e.g. "in the".
+	Phrase string
+	// Length is the number of words of each occurrence.
+	Length int
+	// Index is the byte range [start, end), spanning from the first word of the first occurrence
+	// to the end of the last word of the second occurrence.
+	Index []int
+	// Removal is the byte range [start, end) removed to fix the repetition: the first occurrence,
+	// along with the separator following it.
+	Removal []int
+	// Sole is set by FindAcross if the first occurrence is the only word of the previous line, which the
+	// removal would leave blank.
+	Sole bool
+}
+
+// FindRepetitions identifies the words and phrases of up to length words that are immediately repeated
+// in a string, in order. The words of the phrases are compared pairwise, as stuttering words, and
+// punctuation within or between the occurrences breaks the repetition, except for leading punctuation
+// on the first occurrence and trailing punctuation on the second.
+// At each word, the shortest repetition is reported, and the search resumes at the start of its second
+// occurrence, so that e.g. "in the in the in the" is reported as two adjacent repetitions.
+// A length of less than 1 is treated as 1. If there are no repetitions, it returns nil.
+func FindRepetitions(line string, length int) (repetitions []Repetition) {
+	spans := tokenizeIndex(line)
+	length = max(length, 1)
+
+	for i := 0; i < len(spans); {
+		size := repeated(line, spans[i:], length)
+		if size == 0 {
+			i++
+
+			continue
+		}
+
+		first, second := words(line, spans[i:i+size]), words(line, spans[i+size:i+2*size])
+		leading, _, _ := split(first[0])
+
+		// Keep the leading punctuation of the first occurrence (e.g. an opening bracket).
+		first[0] = first[0][len(leading):]
+		start := spans[i][0] + len(leading)
+
+		phrase := make([]string, size)
+		for j, word := range first {
+			phrase[j] = strings.ToLower(core(word))
+		}
+
+		repetitions = append(repetitions, Repetition{
+			Stutter: format(first, second),
+			Phrase:  strings.Join(phrase, " "),
+			Length:  size,
+			Index:   []int{start, spans[i+2*size-1][1]},
+			Removal: []int{start, spans[i+size][0]},
+		})
+
+		i += size
+	}
+
+	return repetitions
+}
+
+// repeated returns the number of words of the shortest phrase of up to length words at the start of the spans
+// that is immediately repeated, or 0 if there is none.
+func repeated(line string, spans [][2]int, length int) int {
+	for size := 1; size <= length && 2*size <= len(spans); size++ {
+		if isRepeatedPhrase(line, spans[:size], spans[size:2*size]) {
+			return size
+		}
+	}
+
+	return 0
+}
+
+// isRepeatedPhrase checks if the words of two phrases of the same length form stuttering pairs,
+// with punctuation only allowed before the first phrase and after the second.
+func isRepeatedPhrase(line string, first, second [][2]int) bool {
+	for i := range first {
+		if !isStutteringPair(line[first[i][0]:first[i][1]], line[second[i][0]:second[i][1]], i == 0, i == len(first)-1) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// words returns the words at the spans.
+func words(line string, spans [][2]int) []string {
+	words := make([]string, len(spans))
+	for i, span := range spans {
+		words[i] = line[span[0]:span[1]]
+	}
+
+	return words
+}
+
+// format formats a repetition as "(first second)", with the words separated by single spaces.
+func format(first, second []string) string {
+	return "(" + strings.Join(append(slices.Clone(first), second...), " ") + ")"
+}
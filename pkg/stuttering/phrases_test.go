@@ -0,0 +1,113 @@
+package stuttering_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/pkg/stuttering"
+)
+
+func TestFindRepetitions(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string                  // Name of the test case (for logging)
+		line     string                  // Line to check
+		length   int                     // Maximum number of words of a phrase
+		expected []stuttering.Repetition // The repetitions identified
+		trimmed  string                  // The line with the removals applied
+	}{
+		{
+			name:    "no repetition",
+			line:    "in the end",
+			length:  3,
+			trimmed: "in the end",
+		},
+		{
+			name:   "repeated word",
+			line:   "the the end",
+			length: 3,
+			expected: []stuttering.Repetition{
+				{Stutter: "(the the)", Phrase: "the", Length: 1, Index: []int{0, 7}, Removal: []int{0, 4}},
+			},
+			trimmed: "the end",
+		},
+		{
+			name:   "repeated phrase",
+			line:   "it is in the  in the box",
+			length: 3,
+			expected: []stuttering.Repetition{
+				{Stutter: "(in the in the)", Phrase: "in the", Length: 2, Index: []int{6, 20}, Removal: []int{6, 14}},
+			},
+			trimmed: "it is in the box",
+		},
+		{
+			name:   "repeated phrase with case and punctuation",
+			line:   "It Can be can be.",
+			length: 2,
+			expected: []stuttering.Repetition{
+				{Stutter: "(Can be can be.)", Phrase: "can be", Length: 2, Index: []int{3, 17}, Removal: []int{3, 10}},
+			},
+			trimmed: "It can be.",
+		},
+		{
+			name:    "phrase longer than the length",
+			line:    "one of the one of the",
+			length:  2,
+			trimmed: "one of the one of the",
+		},
+		{
+			name:   "phrase repeated three times",
+			line:   "in the in the in the",
+			length: 3,
+			expected: []stuttering.Repetition{
+				{Stutter: "(in the in the)", Phrase: "in the", Length: 2, Index: []int{0, 13}, Removal: []int{0, 7}},
+				{Stutter: "(in the in the)", Phrase: "in the", Length: 2, Index: []int{7, 20}, Removal: []int{7, 14}},
+			},
+			trimmed: "in the",
+		},
+		{
+			name:   "words only with a length of 0",
+			line:   "a a b c b c",
+			length: 0,
+			expected: []stuttering.Repetition{
+				{Stutter: "(a a)", Phrase: "a", Length: 1, Index: []int{0, 3}, Removal: []int{0, 2}},
+			},
+			trimmed: "a b c b c",
+		},
+		{
+			name:   "bracketed phrase",
+			line:   "see (in the in the) box",
+			length: 3,
+			expected: []stuttering.Repetition{
+				{Stutter: "(in the in the))", Phrase: "in the", Length: 2, Index: []int{5, 19}, Removal: []int{5, 12}},
+			},
+			trimmed: "see (in the) box",
+		},
+		{
+			name:    "punctuation within the phrase",
+			line:    "in the, in the",
+			length:  3,
+			trimmed: "in the, in the",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repetitions := stuttering.FindRepetitions(tc.line, tc.length)
+			require.Equal(t, tc.expected, repetitions)
+
+			trimmed, previous := "", 0
+
+			for _, repetition := range repetitions {
+				trimmed += tc.line[previous:repetition.Removal[0]]
+				previous = repetition.Removal[1]
+			}
+
+			require.Equal(t, tc.trimmed, trimmed+tc.line[previous:])
+		})
+	}
+}
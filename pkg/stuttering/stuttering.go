@@ -1,11 +1,14 @@
 // Package stuttering provides functions to check for and identify stuttering words in a string.
+//
+// Words are separated by whitespace, and compared by their core, ignoring case: leading and trailing punctuation,
+// quotes and brackets are not part of the core. Punctuation between two words is a sentence or clause boundary,
+// so that e.g. "that. That" and "had, had" are not stutters, while "(the the)" and "the the." are.
 package stuttering
 
 import (
-	"fmt"
-	"regexp"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Has checks if a string has stuttering words.
@@ -14,96 +17,163 @@ func Has(line string) bool {
 	return len(Find(line)) > 0
 }
 
-// Find identifies and returns the stuttering words in a string.
+// Find identifies and returns the stuttering words in a string, formatted as "(word word)".
 // If there are no stuttering words, it returns an empty slice.
 func Find(line string) []string {
-	words := tokenize(line)
 	stutters := []string{}
 
-	// If there are less than two words, there can be no stuttering words.
-	if len(words) <= 1 {
-		return stutters
+	for _, repetition := range FindRepetitions(line, 1) {
+		stutters = append(stutters, repetition.Stutter)
 	}
 
-	for i := range words[:len(words)-1] {
-		firstWord := words[i]
-		secondWord := words[i+1]
+	return stutters
+}
 
-		if isStutteringPair(firstWord, secondWord) {
-			stutter := fmt.Sprintf("(%s %s)", firstWord, secondWord)
-			stutters = append(stutters, stutter)
-		}
+// FindIndex returns the byte ranges of the stuttering pairs in a string, in the same order as Find.
+// Each range is a two-element slice [start, end), spanning from the first word to the end of the second.
+// If there are no stuttering words, it returns nil.
+func FindIndex(line string) (indices [][]int) {
+	for _, repetition := range FindRepetitions(line, 1) {
+		indices = append(indices, repetition.Index)
 	}
 
-	return stutters
+	return indices
 }
 
-// Trim removes the first word of all stuttering pairs from a string.
-// It ensures that the second word of a stutter, including any trailing non-alphabetic characters, is retained.
-// It returns a new string with the first words of stuttering pairs removed.
-func Trim(line string) string {
-	// Identify and store the leading whitespace
-	re := regexp.MustCompile(`^(\s*)`)
-	leadingWhitespace := re.FindString(line)
+// FindAcross checks whether the last word of a line and the first word of the next line form a stuttering pair.
+// The Removal of the repetition is the byte range in previous removed to fix the stutter (the last word, along
+// with the whitespace separating it from the word before it), and its Index is the byte range of the first word
+// in next. If the last word is the only word of previous, Sole is set, as removing it would leave the line blank
+// (e.g. splitting a paragraph), so that the whole line should be removed instead.
+// It returns false if the words do not form a stuttering pair.
+func FindAcross(previous, next string) (Repetition, bool) {
+	last, first := tokenizeIndex(previous), tokenizeIndex(next)
+
+	if len(last) == 0 || len(first) == 0 {
+		return Repetition{}, false
+	}
 
-	line = line[len(leadingWhitespace):]
+	firstWord, secondWord := previous[last[len(last)-1][0]:last[len(last)-1][1]], next[first[0][0]:first[0][1]]
 
-	for Has(line) {
-		words := tokenize(line)
+	// Removing the last word of the line along with leading punctuation would lose the punctuation.
+	if !isStutteringPair(firstWord, secondWord, false, true) {
+		return Repetition{}, false
+	}
 
-		// If there are less than two words, there can be no stuttering words.
-		if len(words) <= 1 {
-			return line
-		}
+	start := last[len(last)-1][0]
+	if len(last) > 1 {
+		start = last[len(last)-2][1]
+	}
 
-		var result []string
+	return Repetition{
+		Stutter: format([]string{firstWord}, []string{secondWord}),
+		Phrase:  strings.ToLower(core(firstWord)),
+		Length:  1,
+		Index:   []int{first[0][0], first[0][1]},
+		Removal: []int{start, last[len(last)-1][1]},
+		Sole:    len(last) == 1,
+	}, true
+}
 
-		for index := 0; index < len(words); index++ {
-			// Check if the next word exists and if it forms a stutter with the current word.
-			firstWord := words[index]
+// TrimIndex returns the byte ranges removed by Trim, in order.
+// Each range is a two-element slice [start, end), spanning from the first word of a stuttering pair
+// (after any opening quotes or brackets) to the start of the second, so that the separator following
+// the first word is removed along with it.
+// If there are no stuttering words, it returns nil.
+func TrimIndex(line string) (indices [][]int) {
+	for _, repetition := range FindRepetitions(line, 1) {
+		indices = append(indices, repetition.Removal)
+	}
 
-			if index < len(words)-1 {
-				if secondWord := words[index+1]; isStutteringPair(firstWord, secondWord) {
-					// If a stutter is found, add the second word (with its non-alphabet characters) to the result.
-					result = append(result, secondWord)
-					index++ // Move to the word after the stutter.
+	return indices
+}
 
-					continue
-				}
-			}
-			// If no stutter is found, add the current word to the result.
-			result = append(result, firstWord)
+// Trim removes the first word of all stuttering pairs from a string, along with the separator following it.
+// It ensures that the second word of a stutter, including any trailing non-alphabetic characters, is retained.
+// The rest of the string, including its spacing, is left untouched.
+// It returns a new string with the first words of stuttering pairs removed.
+func Trim(line string) string {
+	var (
+		result   strings.Builder
+		previous int
+	)
+
+	for _, index := range TrimIndex(line) {
+		result.WriteString(line[previous:index[0]])
+		previous = index[1]
+	}
+
+	result.WriteString(line[previous:])
+
+	return result.String()
+}
+
+// tokenizeIndex returns the byte ranges of the words in a string, separated by whitespace.
+func tokenizeIndex(line string) (spans [][2]int) {
+	start := -1
+
+	for i, r := range line {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
 		}
+	}
 
-		line = strings.Join(result, " ")
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(line)})
 	}
 
-	return leadingWhitespace + line
+	return spans
 }
 
-// tokenize converts a string into a slice of words.
-// This is a helper function and assumes words are separated by whitespace.
-func tokenize(line string) []string {
-	return strings.FieldsFunc(line, unicode.IsSpace)
+// isWordRune reports whether a rune is part of the core of a word, as opposed to surrounding punctuation,
+// quotes or brackets.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
 }
 
-// normalize removes non-alphabet characters from the end of a word and converts to lower-case.
-func normalize2(word string) string {
-	for len(word) > 0 && !unicode.IsLetter(rune(word[len(word)-1])) {
-		word = word[:len(word)-1]
+// split splits a word into its leading punctuation (e.g. opening quotes and brackets), its core,
+// and its trailing punctuation (e.g. sentence or clause punctuation, closing quotes and brackets).
+func split(word string) (leading, core, trailing string) {
+	start := strings.IndexFunc(word, isWordRune)
+	if start < 0 {
+		return word, "", ""
 	}
 
-	return strings.TrimSpace(strings.ToLower(word))
+	end := strings.LastIndexFunc(word, isWordRune)
+	_, size := utf8.DecodeRuneInString(word[end:])
+
+	return word[:start], word[start : end+size], word[end+size:]
 }
 
-// normalize removes non-alphabet characters from the end of a word and converts to lower-case.
-func normalize1(word string) string {
-	return strings.TrimSpace(strings.ToLower(word))
+// core returns the core of a word, without its leading and trailing punctuation.
+func core(word string) string {
+	_, core, _ := split(word)
+
+	return core
 }
 
-// isStutteringPair checks if two words form a stuttering pair.
-// At the moment, the first word is converted to lower-case and compared to the second word
-// with non-alphabet characters removed.
-func isStutteringPair(firstWord, secondWord string) bool {
-	return normalize1(firstWord) == normalize2(secondWord)
+// isStutteringPair checks if two words form a stuttering pair, i.e. their cores are equal, ignoring case,
+// and contain a letter (so that e.g. repeated numbers are not reported).
+// Punctuation between the words is a sentence or clause boundary, so that the pair is broken by trailing
+// punctuation on the first word or leading punctuation on the second. The first word may have leading
+// punctuation (e.g. an opening bracket) if leading is set, and the second word may have trailing punctuation
+// (e.g. a full stop) if trailing is set.
+func isStutteringPair(firstWord, secondWord string, leading, trailing bool) bool {
+	firstLeading, firstCore, firstTrailing := split(firstWord)
+	secondLeading, secondCore, secondTrailing := split(secondWord)
+
+	switch {
+	case firstTrailing != "" || secondLeading != "":
+		return false
+	case !leading && firstLeading != "", !trailing && secondTrailing != "":
+		return false
+	case !strings.ContainsFunc(firstCore, unicode.IsLetter):
+		return false
+	}
+
+	return strings.EqualFold(firstCore, secondCore)
 }
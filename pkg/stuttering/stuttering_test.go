@@ -16,6 +16,8 @@ func TestStuttering(t *testing.T) {
 		line     string   // Line to check
 		has      bool     // Whether the line has stuttering words
 		stutters []string // The stuttering words identified
+		indices  [][]int  // The byte ranges of the stuttering pairs
+		removals [][]int  // The byte ranges removed when trimming
 		trimmed  string   // The line with stuttering words (first occurrence) removed
 	}{
 		{
@@ -32,6 +34,8 @@ func TestStuttering(t *testing.T) {
 			line:     "hello hello", //nolint:dupword // This is a stuttering pair for testing purposes.
 			has:      true,
 			stutters: []string{"(hello hello)"},
+			indices:  [][]int{{0, 11}},
+			removals: [][]int{{0, 6}},
 			trimmed:  "hello",
 		},
 		{
@@ -39,6 +43,8 @@ func TestStuttering(t *testing.T) {
 			line:     "hello hello!",
 			has:      true,
 			stutters: []string{"(hello hello!)"},
+			indices:  [][]int{{0, 12}},
+			removals: [][]int{{0, 6}},
 			trimmed:  "hello!",
 		},
 		{
@@ -46,8 +52,52 @@ func TestStuttering(t *testing.T) {
 			line:     "hey hey! hello hello! hi hi!",
 			has:      true,
 			stutters: []string{"(hey hey!)", "(hello hello!)", "(hi hi!)"},
+			indices:  [][]int{{0, 8}, {9, 21}, {22, 28}},
+			removals: [][]int{{0, 4}, {9, 15}, {22, 25}},
 			trimmed:  "hey! hello! hi!",
 		},
+		{
+			name:     "stuttering pair with spacing",
+			line:     "\tthe  the\tvalue  =  1",
+			has:      true,
+			stutters: []string{"(the the)"},
+			indices:  [][]int{{1, 9}},
+			removals: [][]int{{1, 6}},
+			trimmed:  "\tthe\tvalue  =  1",
+		},
+		{
+			name:     "repeated stuttering words",
+			line:     "a  a\ta   b",
+			has:      true,
+			stutters: []string{"(a a)", "(a a)"},
+			indices:  [][]int{{0, 4}, {3, 6}},
+			removals: [][]int{{0, 3}, {3, 5}},
+			trimmed:  "a   b",
+		},
+		{
+			name:    "sentence and clause boundaries",
+			line:    "I said that. That is what she had, had she not?",
+			trimmed: "I said that. That is what she had, had she not?",
+		},
+		{
+			name:     "quotes and brackets",
+			line:     `say "the the" (or the the)`,
+			has:      true,
+			stutters: []string{`(the the")`, "(the the))"},
+			indices:  [][]int{{5, 13}, {18, 26}},
+			removals: [][]int{{5, 9}, {18, 22}},
+			trimmed:  `say "the" (or the)`,
+		},
+		{
+			name:    "quoted second word",
+			line:    `the "the" keyword`,
+			trimmed: `the "the" keyword`,
+		},
+		{
+			name:    "numbers",
+			line:    "0 0 1 1",
+			trimmed: "0 0 1 1",
+		},
 		{
 			name:    "non-stuttering pairs",
 			line:    "hey hello hi",
@@ -61,7 +111,70 @@ func TestStuttering(t *testing.T) {
 
 			require.Equal(t, tc.has, stuttering.Has(tc.line), "Has() failed: %q", tc.line)
 			require.ElementsMatch(t, tc.stutters, stuttering.Find(tc.line), "Find() failed: %q", tc.line)
+			require.Equal(t, tc.indices, stuttering.FindIndex(tc.line), "FindIndex() failed: %q", tc.line)
+			require.Equal(t, tc.removals, stuttering.TrimIndex(tc.line), "TrimIndex() failed: %q", tc.line)
 			require.Equal(t, tc.trimmed, stuttering.Trim(tc.line), "Trim() failed: %q", tc.line)
 		})
 	}
 }
+
+func TestFindAcross(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string // Name of the test case (for logging)
+		previous string // Line before
+		next     string // Line after
+		stutter  string // The stuttering words identified
+		removal  []int  // The byte range removed from the line before
+		index    []int  // The byte range of the first word of the line after
+		sole     bool   // Whether the word removed is the only word of the line before
+	}{
+		{
+			name:     "no stutter",
+			previous: "configure the",
+			next:     "server",
+		},
+		{
+			name:     "stutter",
+			previous: "configure  the",
+			next:     "  the server",
+			stutter:  "(the the)",
+			removal:  []int{9, 14},
+			index:    []int{2, 5},
+		},
+		{
+			name:     "stutter with punctuation and trailing whitespace",
+			previous: "\tThe\t",
+			next:     "the.",
+			stutter:  "(The the.)",
+			removal:  []int{1, 4},
+			index:    []int{0, 4},
+			sole:     true,
+		},
+		{
+			name:     "sentence boundary",
+			previous: "It is that.",
+			next:     "That is all.",
+		},
+		{
+			name:     "blank line",
+			previous: "the",
+			next:     "  ",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repetition, ok := stuttering.FindAcross(tc.previous, tc.next)
+
+			require.Equal(t, tc.stutter != "", ok)
+			require.Equal(t, tc.stutter, repetition.Stutter)
+			require.Equal(t, tc.removal, repetition.Removal)
+			require.Equal(t, tc.index, repetition.Index)
+			require.Equal(t, tc.sole, repetition.Sole)
+		})
+	}
+}
@@ -3,6 +3,7 @@
 package trailing
 
 import (
+	"bytes"
 	"strings"
 	"unicode"
 )
@@ -18,3 +19,14 @@ func Has(line string) bool {
 func Trim(line string) string {
 	return strings.TrimRightFunc(line, unicode.IsSpace)
 }
+
+// HasBytes is like Has, but operates on a byte slice.
+func HasBytes(line []byte) bool {
+	return len(TrimBytes(line)) != len(line)
+}
+
+// TrimBytes is like Trim, but operates on a byte slice.
+// It returns a subslice of the input, without copying.
+func TrimBytes(line []byte) []byte {
+	return bytes.TrimRightFunc(line, unicode.IsSpace)
+}
@@ -82,6 +82,8 @@ func TestTrailing(t *testing.T) {
 
 			require.Equal(t, tc.has, trailing.Has(tc.line), "Has() failed: %q", tc.line)
 			require.Equal(t, tc.trimmed, trailing.Trim(tc.line), "Trim() failed: %q", tc.line)
+			require.Equal(t, tc.has, trailing.HasBytes([]byte(tc.line)), "HasBytes() failed: %q", tc.line)
+			require.Equal(t, tc.trimmed, string(trailing.TrimBytes([]byte(tc.line))), "TrimBytes() failed: %q", tc.line)
 		})
 	}
 }
@@ -0,0 +1,208 @@
+// Package typos finds common misspellings in a string, using a list of corrections in the format of codespell.
+//
+// Words are runs of letters, possibly joined by apostrophes (e.g. "doesn't"), and are looked up ignoring case.
+// Words that are part of identifiers, i.e. adjacent to digits, underscores or a backslash, or written in mixed
+// case (e.g. "recieveData"), are not reported.
+package typos
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidCorrection is returned when a list of corrections cannot be parsed.
+var ErrInvalidCorrection = errors.New("invalid correction")
+
+// misspellings is the bundled list of corrections.
+//
+//go:embed misspellings.txt
+var misspellings string
+
+// defaultCorrections parses the bundled list of corrections once.
+//
+//nolint:gochecknoglobals // Parsed once, read-only afterwards.
+var defaultCorrections = sync.OnceValue(func() Corrections {
+	corrections, err := Parse(strings.NewReader(misspellings))
+	if err != nil {
+		panic(fmt.Sprintf("parsing bundled misspellings: %v", err))
+	}
+
+	return corrections
+})
+
+// Default returns the bundled list of common misspellings.
+func Default() Corrections {
+	return defaultCorrections()
+}
+
+// Corrections maps lowercase misspellings to their corrections.
+type Corrections map[string][]string
+
+// Parse reads corrections from r, one per line, in the format of codespell:
+//
+//	# Comments and blank lines are ignored.
+//	teh->the              a single correction, fixed automatically
+//	abotu->about, abbot   several corrections, ambiguous and only reported
+//
+// It returns ErrInvalidCorrection for lines without a correction.
+func Parse(r io.Reader) (Corrections, error) {
+	corrections := Corrections{}
+
+	scanner := bufio.NewScanner(r)
+
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		misspelling, replacements, found := strings.Cut(line, "->")
+		misspelling = strings.ToLower(strings.TrimSpace(misspelling))
+
+		if !found || misspelling == "" {
+			return nil, fmt.Errorf("%w: line %d: %q", ErrInvalidCorrection, number, line)
+		}
+
+		var words []string
+
+		for _, word := range strings.Split(replacements, ",") {
+			if word = strings.TrimSpace(word); word != "" {
+				words = append(words, word)
+			}
+		}
+
+		if len(words) == 0 {
+			return nil, fmt.Errorf("%w: line %d: %q", ErrInvalidCorrection, number, line)
+		}
+
+		corrections[misspelling] = words
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading corrections: %w", err)
+	}
+
+	return corrections, nil
+}
+
+// Typo is a misspelled word in a string.
+type Typo struct {
+	// Word is the misspelled word, as found.
+	Word string
+	// Corrections lists the corrections, in the case of the word (e.g. "The" for "Teh").
+	Corrections []string
+	// Index is the byte range [start, end) of the word.
+	Index []int
+}
+
+// Correction returns the correction of the typo, if it is unambiguous.
+func (t Typo) Correction() (string, bool) {
+	if len(t.Corrections) != 1 {
+		return "", false
+	}
+
+	return t.Corrections[0], true
+}
+
+// Find returns the misspelled words in the string, in order.
+func (c Corrections) Find(line string) (typos []Typo) {
+	for _, index := range words(line) {
+		word := line[index[0]:index[1]]
+
+		corrections, ok := c[strings.ToLower(word)]
+		if !ok {
+			continue
+		}
+
+		apply, ok := casing(word)
+		if !ok {
+			continue
+		}
+
+		typo := Typo{Word: word, Index: index}
+		for _, correction := range corrections {
+			typo.Corrections = append(typo.Corrections, apply(correction))
+		}
+
+		typos = append(typos, typo)
+	}
+
+	return typos
+}
+
+// words returns the byte ranges of the words in the string that are not part of identifiers.
+func words(line string) (indices [][]int) {
+	start := -1
+
+	end := func(position int) {
+		before, _ := utf8.DecodeLastRuneInString(line[:start])
+		after, _ := utf8.DecodeRuneInString(line[position:])
+
+		if !isIdentifier(before) && !isIdentifier(after) {
+			indices = append(indices, []int{start, position})
+		}
+
+		start = -1
+	}
+
+	for position, r := range line {
+		switch {
+		case unicode.IsLetter(r):
+			if start < 0 {
+				start = position
+			}
+		// Apostrophes join letters, e.g. "doesn't".
+		case start >= 0 && r == '\'' && isLetter(line[position+1:]):
+		case start >= 0:
+			end(position)
+		}
+	}
+
+	if start >= 0 {
+		end(len(line))
+	}
+
+	return indices
+}
+
+// isLetter returns true if the string starts with a letter.
+func isLetter(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+
+	return unicode.IsLetter(r)
+}
+
+// isIdentifier returns true if a word next to the rune is part of an identifier or an escape sequence
+// (e.g. "\n"), i.e. the rune is a digit, an underscore or a backslash.
+func isIdentifier(r rune) bool {
+	return r == '\\' || r == '_' || unicode.IsDigit(r)
+}
+
+// casing returns the function applying the case of the word to a correction: lowercase, capitalized or uppercase.
+// It returns false for words in mixed case, which are likely identifiers.
+func casing(word string) (func(string) string, bool) {
+	first, size := utf8.DecodeRuneInString(word)
+	rest := word[size:]
+
+	switch {
+	case word == strings.ToLower(word):
+		return func(s string) string { return s }, true
+	case len(rest) > 0 && word == strings.ToUpper(word):
+		return strings.ToUpper, true
+	case unicode.IsUpper(first) && rest == strings.ToLower(rest):
+		return func(s string) string {
+			r, size := utf8.DecodeRuneInString(s)
+
+			return string(unicode.ToUpper(r)) + s[size:]
+		}, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,113 @@
+package typos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/pkg/typos"
+)
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name  string       // Name of the test case (for logging)
+		line  string       // Line to check
+		typos []typos.Typo // Typos expected
+	}{
+		{
+			name: "no typo",
+			line: "The value is received.",
+		},
+		{
+			name: "lowercase",
+			line: "the teh end",
+			typos: []typos.Typo{
+				{Word: "teh", Corrections: []string{"the"}, Index: []int{4, 7}},
+			},
+		},
+		{
+			name: "capitalized and uppercase",
+			line: "Recieve, TEH",
+			typos: []typos.Typo{
+				{Word: "Recieve", Corrections: []string{"Receive"}, Index: []int{0, 7}},
+				{Word: "TEH", Corrections: []string{"THE"}, Index: []int{9, 12}},
+			},
+		},
+		{
+			name: "mixed case",
+			line: "recieveData ReCieve",
+		},
+		{
+			name: "apostrophe",
+			line: "it doens't work, 'teh'",
+			typos: []typos.Typo{
+				{Word: "doens't", Corrections: []string{"doesn't"}, Index: []int{3, 10}},
+				{Word: "teh", Corrections: []string{"the"}, Index: []int{18, 21}},
+			},
+		},
+		{
+			name: "ambiguous",
+			line: "wether",
+			typos: []typos.Typo{
+				{Word: "wether", Corrections: []string{"whether", "weather"}, Index: []int{0, 6}},
+			},
+		},
+		{
+			name: "identifiers",
+			line: "teh_value teh2 2teh \\nteh",
+		},
+		{
+			name: "non-ASCII letters",
+			line: "café teh",
+			typos: []typos.Typo{
+				{Word: "teh", Corrections: []string{"the"}, Index: []int{6, 9}},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.typos, typos.Default().Find(tc.line))
+		})
+	}
+}
+
+func TestTypo_Correction(t *testing.T) {
+	t.Parallel()
+
+	correction, ok := typos.Typo{Corrections: []string{"the"}}.Correction()
+	require.True(t, ok)
+	require.Equal(t, "the", correction)
+
+	_, ok = typos.Typo{Corrections: []string{"whether", "weather"}}.Correction()
+	require.False(t, ok)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	corrections, err := typos.Parse(strings.NewReader("# Comment\n\nTeh->the\nwether->whether, weather,\n"))
+	require.NoError(t, err)
+	require.Equal(t, typos.Corrections{"teh": {"the"}, "wether": {"whether", "weather"}}, corrections)
+
+	for _, invalid := range []string{"teh", "->the", "teh->", "teh-> , "} {
+		_, err := typos.Parse(strings.NewReader(invalid))
+		require.ErrorIs(t, err, typos.ErrInvalidCorrection, "line %q", invalid)
+	}
+}
+
+func TestParseWords(t *testing.T) {
+	t.Parallel()
+
+	words, err := typos.ParseWords(strings.NewReader("# Comment\n\nwslint\n~Unpatch\n+prefix*\n!forbidden\n"))
+	require.NoError(t, err)
+	require.Equal(t, typos.Words{"wslint": {}, "unpatch": {}, "prefix": {}}, words)
+
+	require.True(t, words.Contains("WsLint"))
+	require.False(t, words.Contains("forbidden"))
+}
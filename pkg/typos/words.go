@@ -0,0 +1,49 @@
+package typos
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Words is a set of words that are not reported as misspelled, compared ignoring case.
+type Words map[string]struct{}
+
+// Contains returns true if the set contains the word, ignoring case.
+func (w Words) Contains(word string) bool {
+	_, ok := w[strings.ToLower(word)]
+
+	return ok
+}
+
+// ParseWords reads words from r, in the format of the word lists of cspell (e.g. settings/project-words):
+//
+//	# Comments and blank lines are ignored.
+//	wslint                an allowed word
+//	~Unpatch              the markers of cspell ("~", "+" and "*") are removed
+//	!forbidden            forbidden words are ignored
+func ParseWords(r io.Reader) (Words, error) {
+	words := Words{}
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		for _, word := range strings.Fields(line) {
+			if word = strings.Trim(word, "~+*"); word != "" {
+				words[strings.ToLower(word)] = struct{}{}
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading words: %w", err)
+	}
+
+	return words, nil
+}
@@ -0,0 +1,40 @@
+package wslint
+
+// Header is the header (e.g. a license notice) the files must start with, possibly after a shebang or an XML
+// declaration.
+type Header struct {
+	// Template is the text of the header, without the comment syntax.
+	// The placeholder "{{year}}" stands for any year when checking, and the current one when fixing.
+	Template string
+	// Comment prefixes each line of the template, e.g. "// " or "# ".
+	Comment string
+	// Start and End are the lines enclosing the header, if not empty, e.g. "<!--" and "-->".
+	Start, End string
+	// Within is the number of lines (following a shebang or XML declaration) the header must start within.
+	// If 0, the header must start within the first 10 lines.
+	Within int
+	// Include lists the glob patterns of the paths the header applies to. It applies to all paths if empty.
+	Include []string
+	// Exclude lists the glob patterns of the paths the header does not apply to, taking precedence over Include.
+	Exclude []string
+}
+
+// Applies returns true if the header applies to the file with the given path.
+// The path is matched against the glob patterns with "./" removed.
+func (h Header) Applies(path string) bool {
+	return applies(h.Include, h.Exclude, path)
+}
+
+// Headers is a list of headers, e.g. one for each type of file.
+type Headers []Header
+
+// For returns the headers that apply to the file with the given path.
+func (h Headers) For(path string) (headers Headers) {
+	for _, header := range h {
+		if header.Applies(path) {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
@@ -0,0 +1,305 @@
+package wslint
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/pkg/gitattributes"
+	"github.com/idelchi/wslint/pkg/stuttering"
+)
+
+// ErrUnknownChecker is returned when a configuration refers to a checker that is not registered.
+var ErrUnknownChecker = errors.New("unknown checker")
+
+// ErrNoCheckers is returned when the configuration selects no checkers.
+var ErrNoCheckers = errors.New("no checkers selected")
+
+// ErrDuplicateChecker is returned when a checker is registered twice under the same name.
+var ErrDuplicateChecker = errors.New("checker already registered")
+
+// Factory creates a new checker from the configuration.
+// It may return a nil checker to disable the checker for the configuration.
+type Factory func(cfg Config) (Checker, error)
+
+// registration holds a registered checker.
+type registration struct {
+	// name is the name the checker is registered and reported under.
+	name string
+	// factory creates the checker.
+	factory Factory
+	// experimental checkers are only enabled by default if Config.Experimental is set.
+	experimental bool
+	// rules creates further checkers from the configuration, each reported under its own name.
+	// They follow the checker in the pipeline, and are selected by their own names or the name of the checker.
+	rules func(cfg Config) []NamedChecker
+}
+
+// registry keeps track of the available checkers, in the order of their registration.
+type registry struct {
+	mu      sync.RWMutex
+	entries []registration
+}
+
+// defaultRegistry is shared by the command line interface and the embeddable API.
+//
+//nolint:gochecknoglobals // The registry is global by design, to allow registering custom checkers.
+var defaultRegistry = &registry{
+	// The built-in checkers are ordered such that the fixes of one checker do not create violations
+	// for the preceding ones: the header is inserted first, to be checked like any other lines, correcting
+	// typos can create stutters (e.g. "teh the"), removing stutters can leave trailing whitespace, and
+	// removing trailing whitespace can create trailing blank lines.
+	entries: []registration{
+		{
+			name: "header",
+			factory: func(cfg Config) (Checker, error) {
+				if len(cfg.Headers) == 0 {
+					return nil, nil //nolint:nilnil // No header is required.
+				}
+
+				header := cfg.Headers[0]
+
+				checker := checkers.Header{
+					Template: header.Template,
+					Comment:  header.Comment,
+					Start:    header.Start,
+					End:      header.End,
+					Within:   header.Within,
+				}
+
+				return checker, checker.Validate() //nolint:wrapcheck // The error is wrapped by the registry.
+			},
+		},
+		{
+			name: "typos",
+			factory: func(cfg Config) (Checker, error) {
+				return checkers.Typos{Words: cfg.TyposWords, Syntax: cfg.Syntax}, nil
+			},
+			experimental: true,
+		},
+		{
+			name: "stutter",
+			factory: func(cfg Config) (Checker, error) {
+				exceptions, err := stuttering.LanguageExceptions(cfg.StutterLanguages...)
+				if err != nil {
+					return nil, err //nolint:wrapcheck // The error is wrapped by the registry.
+				}
+
+				return checkers.Stutter{
+					Exceptions: append(exceptions, cfg.StutterExceptions...),
+					Length:     cfg.StutterLength,
+					Syntax:     cfg.Syntax,
+				}, nil
+			},
+			experimental: true,
+		},
+		{
+			name: "whitespace",
+			factory: func(cfg Config) (Checker, error) {
+				rules := whitespace(cfg)
+				if !rules.BlankAtEOL && !rules.SpaceBeforeTab {
+					return nil, nil //nolint:nilnil // Disabled by the whitespace rules.
+				}
+
+				return checkers.Whitespace{
+					IgnoreTrailing: !rules.BlankAtEOL,
+					SpaceBeforeTab: rules.SpaceBeforeTab,
+					CRAtEOL:        rules.CRAtEOL,
+				}, nil
+			},
+		},
+		{
+			name: "blanks",
+			factory: func(cfg Config) (Checker, error) {
+				if !whitespace(cfg).BlankAtEOF {
+					return nil, nil //nolint:nilnil // Disabled by the whitespace rules.
+				}
+
+				return checkers.Blanks{}, nil
+			},
+		},
+		{
+			// Conflict markers are not fixed, so their position does not matter.
+			name: "conflicts",
+			factory: func(Config) (Checker, error) {
+				return checkers.Conflicts{}, nil
+			},
+		},
+		{
+			// The user-defined rules are checkers of their own, selected together by "rules".
+			name: "rules",
+			factory: func(Config) (Checker, error) {
+				return nil, nil //nolint:nilnil // The rules are created separately.
+			},
+			rules: func(cfg Config) (rules []NamedChecker) {
+				for _, rule := range cfg.Rules {
+					rules = append(rules, NamedChecker{
+						Name: rule.Name,
+						Checker: checkers.Rule{
+							Pattern:     rule.Pattern,
+							Message:     rule.Message,
+							Replacement: rule.Replacement,
+							Severity:    rule.Severity,
+						},
+					})
+				}
+
+				return rules
+			},
+		},
+	},
+}
+
+// Register adds a custom checker under the given name, at the end of the pipeline.
+// The checker is enabled by default, and can be selected explicitly through Config.Checkers.
+// It returns an error if a checker with the same name is already registered.
+func Register(name string, factory Factory) error {
+	return defaultRegistry.register(registration{name: name, factory: factory})
+}
+
+// Registered returns the names of all registered checkers, in the order of their registration.
+func Registered() []string {
+	return defaultRegistry.names()
+}
+
+// Description describes a checker available for a configuration.
+type Description struct {
+	// Name is the name the checker is selected by and reported under.
+	Name string
+	// Experimental checkers are only enabled by default if Config.Experimental is set.
+	Experimental bool
+	// Rule is set for the user-defined rules of the configuration.
+	Rule bool
+}
+
+// Describe returns the checkers available for the configuration, in the order of the pipeline,
+// including the user-defined rules of the configuration.
+func Describe(cfg Config) []Description {
+	return defaultRegistry.describe(cfg)
+}
+
+// NewCheckers creates the pipeline of checkers selected by the configuration, in the order of their registration,
+// with the rules of the configuration (Config.Rules) following the built-in checkers.
+// If Config.Checkers is empty, all checkers enabled by default are created, including the experimental
+// ones if Config.Experimental is set.
+func NewCheckers(cfg Config) ([]NamedChecker, error) {
+	return defaultRegistry.build(cfg)
+}
+
+func (r *registry) register(entry registration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slices.ContainsFunc(r.entries, func(e registration) bool { return e.name == entry.name }) {
+		return fmt.Errorf("%w: %q", ErrDuplicateChecker, entry.name)
+	}
+
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+func (r *registry) names() (names []string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.entries {
+		names = append(names, entry.name)
+	}
+
+	return names
+}
+
+func (r *registry) describe(cfg Config) (descriptions []Description) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.entries {
+		descriptions = append(descriptions, Description{Name: entry.name, Experimental: entry.experimental})
+
+		if entry.rules == nil {
+			continue
+		}
+
+		for _, rule := range entry.rules(cfg) {
+			descriptions = append(descriptions, Description{Name: rule.Name, Experimental: entry.experimental, Rule: true})
+		}
+	}
+
+	return descriptions
+}
+
+func (r *registry) build(cfg Config) ([]NamedChecker, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([][]NamedChecker, len(r.entries))
+	known := make(map[string]bool)
+
+	for _, entry := range r.entries {
+		known[entry.name] = true
+	}
+
+	for i, entry := range r.entries {
+		if entry.rules == nil {
+			continue
+		}
+
+		rules[i] = entry.rules(cfg)
+
+		for _, rule := range rules[i] {
+			if known[rule.Name] {
+				return nil, fmt.Errorf("%w: %q", ErrDuplicateChecker, rule.Name)
+			}
+
+			known[rule.Name] = true
+		}
+	}
+
+	for _, name := range cfg.Checkers {
+		if !known[name] {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownChecker, name)
+		}
+	}
+
+	var selected []NamedChecker
+
+	for i, entry := range r.entries {
+		// The rules of experimental checkers are experimental as well.
+		if len(cfg.Checkers) == 0 && entry.experimental && !cfg.Experimental {
+			continue
+		}
+
+		all := len(cfg.Checkers) == 0 || slices.Contains(cfg.Checkers, entry.name)
+
+		if all {
+			checker, err := entry.factory(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("creating checker %q: %w", entry.name, err)
+			}
+
+			if checker != nil {
+				selected = append(selected, NamedChecker{Name: entry.name, Checker: checker})
+			}
+		}
+
+		for _, rule := range rules[i] {
+			if all || slices.Contains(cfg.Checkers, rule.Name) {
+				selected = append(selected, rule)
+			}
+		}
+	}
+
+	return selected, nil
+}
+
+// whitespace returns the whitespace rules of the configuration.
+func whitespace(cfg Config) gitattributes.Whitespace {
+	if cfg.Whitespace == nil {
+		return gitattributes.DefaultWhitespace
+	}
+
+	return *cfg.Whitespace
+}
@@ -0,0 +1,79 @@
+package wslint
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/idelchi/wslint/internal/linter"
+)
+
+// Severity is the severity of an issue: errors fail the run, while warnings are only reported.
+type Severity = linter.Severity
+
+const (
+	// SeverityError is the severity of issues that fail the run, and the default.
+	SeverityError = linter.Error
+	// SeverityWarning is the severity of issues that are reported, but do not fail the run.
+	SeverityWarning = linter.Warning
+)
+
+// Rule is a user-defined regular expression that must not match the content (e.g. leftover debug statements),
+// reported under its own name.
+type Rule struct {
+	// Name is the name the matches are reported under, e.g. "no-console-log".
+	Name string
+	// Message describes the matches. If empty, they are described as forbidden.
+	Message string
+	// Pattern is the regular expression, matched line by line.
+	Pattern *regexp.Regexp
+	// Include lists the glob patterns of the paths the rule applies to. It applies to all paths if empty.
+	Include []string
+	// Exclude lists the glob patterns of the paths the rule does not apply to, taking precedence over Include.
+	Exclude []string
+	// Severity is the severity of the matches.
+	Severity Severity
+	// Replacement is the template the matches are replaced with when fixing, with $1 or ${name} expanded to the
+	// submatches. If nil, the matches are not fixed.
+	Replacement *string
+}
+
+// Applies returns true if the rule applies to the file with the given path.
+// The path is matched against the glob patterns with "./" removed.
+func (r Rule) Applies(path string) bool {
+	return applies(r.Include, r.Exclude, path)
+}
+
+// applies returns true if the path matches any of the included glob patterns (or there are none),
+// and none of the excluded ones. The path is matched with "./" removed.
+func applies(include, exclude []string, path string) bool {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "./")
+
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if matched, _ := doublestar.Match(pattern, path); matched {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return (len(include) == 0 || matches(include)) && !matches(exclude)
+}
+
+// Rules is a list of rules.
+type Rules []Rule
+
+// For returns the rules that apply to the file with the given path.
+func (r Rules) For(path string) (rules Rules) {
+	for _, rule := range r {
+		if rule.Applies(path) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
@@ -0,0 +1,174 @@
+// Package wslint provides an embeddable API to lint and fix in-memory content with the wslint checkers.
+//
+// It uses the same checker registry as the wslint command line interface, and allows registering
+// custom checkers through Register.
+//
+// Example:
+//
+//	result, err := wslint.Fix(ctx, "README.md", content, wslint.Config{})
+//	if err != nil {
+//		return err
+//	}
+//
+//	for _, issue := range result.Issues {
+//		fmt.Printf("%s:%d:%d: %s: %s\n", result.Name, issue.Line, issue.Column, issue.Checker, issue.Message)
+//	}
+//
+//	os.WriteFile("README.md", result.Fixed, 0o600)
+package wslint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/idelchi/wslint/internal/linter"
+	"github.com/idelchi/wslint/pkg/gitattributes"
+	"github.com/idelchi/wslint/pkg/prose"
+	"github.com/idelchi/wslint/pkg/stuttering"
+	"github.com/idelchi/wslint/pkg/typos"
+)
+
+// Checker is the interface implemented by all checkers.
+// Check receives the lines of the content (split on "\n") and returns the issues found,
+// each along with the edits fixing it. Checkers must not modify the received lines.
+type Checker = linter.Checker
+
+// NamedChecker is a checker along with the name it reports its issues under.
+type NamedChecker = linter.NamedChecker
+
+// ErrNotConverged is returned by Fix when the fixes still change the content after the maximum number of passes.
+var ErrNotConverged = linter.ErrNotConverged
+
+// Config selects and configures the checkers to run.
+type Config struct {
+	// Checkers lists the names of the checkers to run.
+	// If empty, all checkers enabled by default are run.
+	Checkers []string
+	// Experimental enables the experimental checkers, if Checkers is empty.
+	Experimental bool
+	// StutterExceptions lists the repeated words and phrases to ignore (see stuttering.ParseExceptions).
+	// Lint and Fix only apply the exceptions whose scope matches the name, while NewCheckers applies all of them.
+	StutterExceptions stuttering.Exceptions
+	// StutterLength is the maximum number of words of a repeated phrase reported by the stutter checker.
+	// If 0, stuttering.DefaultLength applies.
+	StutterLength int
+	// StutterLanguages selects the sets of words and phrases that are legitimately repeated in a language
+	// (e.g. "that that" in English or "die die" in German), by ISO 639-1 code (see stuttering.Languages).
+	StutterLanguages []string
+	// TyposWords lists the words not reported by the typos checker, even if they are common misspellings.
+	TyposWords typos.Words
+	// Syntax restricts the stutter and typos checkers to the prose of the content, e.g. the comments and strings of
+	// source code (see prose.Syntax). If empty, Lint and Fix detect it from the name, and NewCheckers checks
+	// all text.
+	Syntax prose.Syntax
+	// Rules lists the user-defined rules, each reported under its own name. The rules are run if Checkers is
+	// empty, or names them or "rules". Lint and Fix only run the rules applying to the name, while NewCheckers
+	// runs all of them.
+	Rules Rules
+	// Headers lists the headers the content must start with, e.g. one for each type of file, of which the
+	// header checker requires the first. Lint and Fix only consider the headers applying to the name.
+	Headers Headers
+	// Whitespace selects the whitespace rules of the whitespace and blanks checkers, e.g. as resolved from
+	// the whitespace attribute of git. If nil, the default rules (gitattributes.DefaultWhitespace) apply.
+	Whitespace *gitattributes.Whitespace
+}
+
+// Issue is a single issue reported by a checker, located by its 1-based line and (byte) columns.
+type Issue = linter.Issue
+
+// Edit replaces the text between two positions, as part of the fix of an issue.
+type Edit = linter.Edit
+
+// Result is the outcome of linting a piece of content.
+type Result struct {
+	// Name is the name the content was linted under.
+	Name string
+	// Issues lists the issues found, grouped by checker in the order of the pipeline.
+	Issues []Issue
+	// Fixed is the content with all issues fixed. It is only set by Fix.
+	Fixed []byte
+}
+
+// HasIssues returns true if any issues were found.
+func (r Result) HasIssues() bool {
+	return len(r.Issues) > 0
+}
+
+// Lint checks the content with the checkers selected by the configuration and returns the issues found.
+// The name is used for reporting only.
+func Lint(ctx context.Context, name string, content []byte, cfg Config) (Result, error) {
+	result, _, err := run(ctx, name, content, cfg)
+
+	// The fixes are not applied, so whether they converge does not matter.
+	if errors.Is(err, ErrNotConverged) {
+		return result, nil
+	}
+
+	return result, err
+}
+
+// Fix checks the content with the checkers selected by the configuration and returns the issues found,
+// along with the fixed content.
+// It returns ErrNotConverged, along with the issues found but without fixed content, if the fixes still change
+// the content after the maximum number of passes.
+// The name is used for reporting only.
+func Fix(ctx context.Context, name string, content []byte, cfg Config) (Result, error) {
+	result, lines, err := run(ctx, name, content, cfg)
+	if err != nil {
+		return result, err
+	}
+
+	result.Fixed = bytes.Join(lines, []byte("\n"))
+
+	return result, nil
+}
+
+// Apply applies the edits to the content and returns the resulting content.
+// Edits overlapping an edit before them are not applied, and their indices are returned as conflicts.
+func Apply(content []byte, edits []Edit) (fixed []byte, conflicts []int) {
+	lines, conflicts := linter.Apply(bytes.Split(content, []byte("\n")), edits)
+
+	return bytes.Join(lines, []byte("\n")), conflicts
+}
+
+// run applies the configured checkers to the content and returns the result along with the formatted lines.
+// It returns ErrNotConverged along with both if the fixes do not converge.
+func run(ctx context.Context, name string, content []byte, cfg Config) (Result, [][]byte, error) {
+	result := Result{Name: name}
+
+	if err := ctx.Err(); err != nil {
+		return result, nil, err
+	}
+
+	if cfg.Syntax == "" {
+		cfg.Syntax = prose.Detect(name)
+	}
+
+	cfg.StutterExceptions = cfg.StutterExceptions.For(name)
+	cfg.Rules = cfg.Rules.For(name)
+	cfg.Headers = cfg.Headers.For(name)
+
+	checkers, err := NewCheckers(cfg)
+	if err != nil {
+		return result, nil, err
+	}
+
+	if len(checkers) == 0 {
+		return result, nil, ErrNoCheckers
+	}
+
+	lint := linter.New(name, checkers)
+
+	lines, converged := lint.Format(bytes.Split(content, []byte("\n")))
+
+	if err := ctx.Err(); err != nil {
+		return result, nil, err
+	}
+
+	for _, checker := range checkers {
+		result.Issues = append(result.Issues, lint.Issues[checker.Name]...)
+	}
+
+	return result, lines, converged
+}
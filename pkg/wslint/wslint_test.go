@@ -0,0 +1,286 @@
+package wslint_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/idelchi/wslint/internal/checkers"
+	"github.com/idelchi/wslint/pkg/wslint"
+)
+
+// Shouting is a custom checker that reports and lowercases upper-case lines.
+type Shouting struct{}
+
+// Check reports upper-case lines, with an edit lowercasing them.
+func (Shouting) Check(lines [][]byte) []wslint.Issue {
+	var issues []wslint.Issue
+
+	for i, line := range lines {
+		if len(line) > 0 && bytes.Equal(line, bytes.ToUpper(line)) {
+			issues = append(issues, wslint.Issue{
+				Line:      i + 1,
+				Column:    1,
+				EndColumn: len(line) + 1,
+				Message:   "shouting",
+				Edits: []wslint.Edit{
+					{Line: i + 1, Column: 1, EndLine: i + 1, EndColumn: len(line) + 1, Text: string(bytes.ToLower(line))},
+				},
+			})
+		}
+	}
+
+	return issues
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string // Name of the test case (for logging)
+		content string // Content to lint
+		cfg     wslint.Config
+		issues  []wslint.Issue // Issues expected to be reported, in order
+		fixed   string         // Content expected after fixing
+	}{
+		{
+			name:    "clean",
+			content: "clean\n",
+			fixed:   "clean\n",
+		},
+		{
+			name:    "trailing whitespace and missing blank line",
+			content: "dirty \nline",
+			issues: []wslint.Issue{
+				{
+					Checker: "whitespace", Line: 1, Column: 6, EndColumn: 7,
+					Message: checkers.ErrHasTrailing.Error(), Fix: "remove the trailing whitespace",
+					Edits: []wslint.Edit{{Line: 1, Column: 6, EndLine: 1, EndColumn: 7}},
+				},
+				{
+					Checker: "blanks", Line: 2, Column: 5, EndColumn: 5,
+					Message: checkers.ErrTooFewBlanks.Error(), Fix: "add a blank line",
+					Edits: []wslint.Edit{{Line: 2, Column: 5, EndLine: 2, EndColumn: 5, Text: "\n"}},
+				},
+			},
+			fixed: "dirty\nline\n",
+		},
+		{
+			name:    "stutter is experimental",
+			content: "the the line\n",
+			fixed:   "the the line\n",
+		},
+		{
+			name:    "stutter selected explicitly",
+			content: "the the line\n",
+			cfg:     wslint.Config{Checkers: []string{"stutter"}},
+			issues: []wslint.Issue{
+				{
+					Checker: "stutter", Line: 1, Column: 1, EndColumn: 8,
+					Message: checkers.ErrStutter.Error() + " (the the)", Fix: "remove the repeated word",
+					Edits: []wslint.Edit{{Line: 1, Column: 1, EndLine: 1, EndColumn: 5}},
+				},
+			},
+			fixed: "the line\n",
+		},
+		{
+			name:    "stutter in the comments of code.go",
+			content: "x x // the the\n",
+			cfg:     wslint.Config{Checkers: []string{"stutter"}},
+			issues: []wslint.Issue{
+				{
+					Checker: "stutter", Line: 1, Column: 8, EndColumn: 15,
+					Message: checkers.ErrStutter.Error() + " (the the)", Fix: "remove the repeated word",
+					Edits: []wslint.Edit{{Line: 1, Column: 8, EndLine: 1, EndColumn: 12}},
+				},
+			},
+			fixed: "x x // the\n",
+		},
+		{
+			name:    "typos selected explicitly",
+			content: "teh line\n",
+			cfg:     wslint.Config{Checkers: []string{"typos"}},
+			issues: []wslint.Issue{
+				{
+					Checker: "typos", Line: 1, Column: 1, EndColumn: 4,
+					Message: checkers.ErrTypo.Error() + " (teh)", Fix: `replace with "the"`,
+					Edits: []wslint.Edit{{Line: 1, Column: 1, EndLine: 1, EndColumn: 4, Text: "the"}},
+				},
+			},
+			fixed: "the line\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := wslint.Lint(context.Background(), tc.name, []byte(tc.content), tc.cfg)
+			require.NoError(t, err)
+			require.Nil(t, result.Fixed)
+			require.Equal(t, len(tc.issues) > 0, result.HasIssues())
+			require.Equal(t, tc.issues, result.Issues)
+
+			result, err = wslint.Fix(context.Background(), tc.name, []byte(tc.content), tc.cfg)
+			require.NoError(t, err)
+			require.Equal(t, tc.fixed, string(result.Fixed))
+		})
+	}
+}
+
+func TestLint_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := wslint.Lint(context.Background(), "unknown", nil, wslint.Config{Checkers: []string{"unknown"}})
+	require.ErrorIs(t, err, wslint.ErrUnknownChecker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = wslint.Lint(ctx, "cancelled", nil, wslint.Config{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLint_Rules(t *testing.T) {
+	t.Parallel()
+
+	replacement := "logger.debug("
+
+	rules := wslint.Rules{
+		{
+			Name: "no-console-log", Message: "debug output", Pattern: regexp.MustCompile(`console\.log\(`),
+			Include: []string{"**/*.js"}, Exclude: []string{"vendor/**"}, Replacement: &replacement,
+		},
+		{Name: "fixme-now", Pattern: regexp.MustCompile(`FIXME-NOW`), Severity: wslint.SeverityWarning},
+	}
+
+	content := []byte("console.log(1) // FIXME-NOW\n")
+
+	result, err := wslint.Lint(context.Background(), "src/debug.js", content, wslint.Config{Rules: rules})
+	require.NoError(t, err)
+	require.Equal(t, []wslint.Issue{
+		{
+			Checker: "no-console-log", Line: 1, Column: 1, EndColumn: 13, Message: "debug output (console.log()",
+			Fix: `replace with "logger.debug("`, Edits: []wslint.Edit{{Line: 1, Column: 1, EndLine: 1, EndColumn: 13, Text: replacement}},
+		},
+		{
+			Checker: "fixme-now", Line: 1, Column: 19, EndColumn: 28, Message: checkers.ErrForbidden.Error() + " (FIXME-NOW)",
+			Severity: wslint.SeverityWarning,
+		},
+	}, result.Issues)
+
+	result, err = wslint.Fix(context.Background(), "src/debug.js", content, wslint.Config{Rules: rules})
+	require.NoError(t, err)
+	require.Equal(t, "logger.debug(1) // FIXME-NOW\n", string(result.Fixed))
+
+	// The rules only apply to the paths they include, and not to those they exclude.
+	for _, name := range []string{"debug.go", "vendor/debug.js"} {
+		result, err = wslint.Lint(context.Background(), name, content, wslint.Config{Rules: rules})
+		require.NoError(t, err)
+		require.Len(t, result.Issues, 1, name)
+		require.Equal(t, "fixme-now", result.Issues[0].Checker, name)
+	}
+
+	// The rules are selected by their own names, or all together by "rules".
+	for checkers, count := range map[string]int{"fixme-now": 1, "rules": 2} {
+		result, err = wslint.Lint(context.Background(), "debug.js", content, wslint.Config{
+			Checkers: []string{checkers},
+			Rules:    rules,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Issues, count, checkers)
+	}
+
+	_, err = wslint.Lint(context.Background(), "debug.js", content, wslint.Config{
+		Rules: wslint.Rules{{Name: "blanks", Pattern: regexp.MustCompile(`x`)}},
+	})
+	require.ErrorIs(t, err, wslint.ErrDuplicateChecker)
+}
+
+func TestLint_Headers(t *testing.T) {
+	t.Parallel()
+
+	headers := wslint.Headers{
+		{Template: "SPDX-License-Identifier: MIT", Comment: "# ", Include: []string{"**/*.sh"}},
+		{Template: "SPDX-License-Identifier: MIT", Comment: "// ", Exclude: []string{"vendor/**"}},
+	}
+
+	content := []byte("#!/bin/sh\necho\n")
+
+	result, err := wslint.Fix(context.Background(), "scripts/run.sh", content, wslint.Config{Headers: headers})
+	require.NoError(t, err)
+	require.Equal(t, []wslint.Issue{
+		{
+			Checker: "header", Line: 2, Column: 1, EndColumn: 1, Message: checkers.ErrNoHeader.Error(),
+			Fix: "insert the header", Edits: []wslint.Edit{
+				{Line: 2, Column: 1, EndLine: 2, EndColumn: 1, Text: "# SPDX-License-Identifier: MIT\n\n"},
+			},
+		},
+	}, result.Issues)
+	require.Equal(t, "#!/bin/sh\n# SPDX-License-Identifier: MIT\n\necho\n", string(result.Fixed))
+
+	// The first header applying to the path is required.
+	result, err = wslint.Lint(context.Background(), "main.go", []byte("// SPDX-License-Identifier: MIT\n"), wslint.Config{
+		Headers: headers,
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Issues)
+
+	result, err = wslint.Lint(context.Background(), "vendor/main.go", content, wslint.Config{Headers: headers})
+	require.NoError(t, err)
+	require.Empty(t, result.Issues)
+
+	_, err = wslint.Lint(context.Background(), "main.go", content, wslint.Config{
+		Headers: wslint.Headers{{Template: "Copyright {{owner}}"}},
+	})
+	require.ErrorIs(t, err, checkers.ErrPlaceholder)
+}
+
+func TestRegister(t *testing.T) { //nolint:paralleltest // The checker is registered in the global registry.
+	// The registered checker stays enabled by default for the other tests, so it only checks when selected.
+	factory := func(cfg wslint.Config) (wslint.Checker, error) {
+		if !slices.Contains(cfg.Checkers, "shouting") {
+			return nil, nil //nolint:nilnil // Disabled unless selected explicitly.
+		}
+
+		return Shouting{}, nil
+	}
+
+	// The registry is global, so the checker may already be registered by a previous run (-count).
+	if err := wslint.Register("shouting", factory); err != nil {
+		require.ErrorIs(t, err, wslint.ErrDuplicateChecker)
+	}
+
+	require.ErrorIs(t, wslint.Register("shouting", factory), wslint.ErrDuplicateChecker)
+	require.Contains(t, wslint.Registered(), "shouting")
+
+	result, err := wslint.Fix(context.Background(), "custom", []byte("LOUD\n"), wslint.Config{Checkers: []string{"shouting"}})
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	require.Equal(t, "shouting", result.Issues[0].Checker)
+	require.Equal(t, "loud\n", string(result.Fixed))
+
+	result, err = wslint.Lint(context.Background(), "custom", []byte("LOUD\n"), wslint.Config{})
+	require.NoError(t, err)
+	require.Empty(t, result.Issues)
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("one  \ntwo\n")
+
+	edits := []wslint.Edit{
+		{Line: 1, Column: 4, EndLine: 1, EndColumn: 6},
+		{Line: 1, Column: 5, EndLine: 1, EndColumn: 6, Text: "!"},
+		{Line: 2, Column: 1, EndLine: 2, EndColumn: 4, Text: "TWO"},
+	}
+
+	fixed, conflicts := wslint.Apply(content, edits)
+	require.Equal(t, "one\nTWO\n", string(fixed))
+	require.Equal(t, []int{1}, conflicts)
+}